@@ -15,6 +15,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -32,6 +33,12 @@ func main() {
 	cli.SetVersionInfo(Version, GitCommit, BuildDate)
 
 	if err := cli.Execute(); err != nil {
+		// Results such as detected drift are reported through the exit code
+		var exitErr *cli.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
+
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
@@ -0,0 +1,85 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package aws wraps the few AWS APIs SoloOps calls directly for deploys (S3,
+// CloudFront, Lambda and CloudWatch). Clients are built on aws-sdk-go-v2, so
+// credentials come from the SDK's default chain: environment variables, the
+// shared config and credentials files (profiles, SSO, role_arn,
+// credential_process) and container or instance roles.
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/config"
+)
+
+// Client holds the configuration shared by every service client
+type Client struct {
+	Config awssdk.Config
+	HTTP   *http.Client // downloads presigned URLs; defaults to http.DefaultClient
+}
+
+// NewClient loads the default AWS configuration for region and checks that
+// credentials can be resolved, so a missing login fails before any work starts
+func NewClient(ctx context.Context, region string) (*Client, error) {
+	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(region))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+	if _, err := cfg.Credentials.Retrieve(ctx); err != nil {
+		return nil, fmt.Errorf("no AWS credentials found: %w", err)
+	}
+	return &Client{Config: cfg}, nil
+}
+
+// IsNotFound reports whether err is an HTTP 404 response from an AWS API
+func IsNotFound(err error) bool {
+	var respErr *awshttp.ResponseError
+	return errors.As(err, &respErr) && respErr.HTTPStatusCode() == http.StatusNotFound
+}
+
+// EndpointURL returns the endpoint override for a service from
+// AWS_ENDPOINT_URL_<SERVICE> or AWS_ENDPOINT_URL, or "" for the default.
+// Overrides point SoloOps at local stand-ins such as MinIO or LocalStack; the
+// SDK applies them itself, this only tells callers one is in use.
+func EndpointURL(service string) string {
+	name := "AWS_ENDPOINT_URL_" + strings.ToUpper(strings.ReplaceAll(service, "-", "_"))
+	if endpoint := os.Getenv(name); endpoint != "" {
+		return strings.TrimSuffix(endpoint, "/")
+	}
+	return strings.TrimSuffix(os.Getenv("AWS_ENDPOINT_URL"), "/")
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// optional returns nil for an empty string, for optional API parameters
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,53 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudfront"
+	"github.com/aws/aws-sdk-go-v2/service/cloudfront/types"
+)
+
+// CloudFront is a client for CloudFront cache invalidations
+type CloudFront struct {
+	api *cloudfront.Client
+}
+
+// NewCloudFront creates a CloudFront client
+func NewCloudFront(client *Client) *CloudFront {
+	return &CloudFront{api: cloudfront.NewFromConfig(client.Config)}
+}
+
+// CreateInvalidation invalidates paths in a distribution and returns the
+// invalidation ID. callerReference must be unique per invalidation.
+func (c *CloudFront) CreateInvalidation(ctx context.Context, distributionID, callerReference string, items []string) (string, error) {
+	quantity := int32(len(items))
+	out, err := c.api.CreateInvalidation(ctx, &cloudfront.CreateInvalidationInput{
+		DistributionId: &distributionID,
+		InvalidationBatch: &types.InvalidationBatch{
+			CallerReference: &callerReference,
+			Paths:           &types.Paths{Quantity: &quantity, Items: items},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to invalidate distribution %s: %w", distributionID, err)
+	}
+	if out.Invalidation == nil {
+		return "", nil
+	}
+	return deref(out.Invalidation.Id), nil
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+)
+
+// AlarmStateAlarm is the state of a firing alarm
+const AlarmStateAlarm = "ALARM"
+
+// CloudWatch is a client for reading CloudWatch alarm states
+type CloudWatch struct {
+	api *cloudwatch.Client
+}
+
+// NewCloudWatch creates a CloudWatch client
+func NewCloudWatch(client *Client) *CloudWatch {
+	return &CloudWatch{api: cloudwatch.NewFromConfig(client.Config)}
+}
+
+// MetricAlarm is the state of an alarm
+type MetricAlarm struct {
+	AlarmName   string
+	StateValue  string
+	StateReason string
+}
+
+// DescribeAlarms returns the state of the named alarms
+func (c *CloudWatch) DescribeAlarms(ctx context.Context, names []string) ([]MetricAlarm, error) {
+	var alarms []MetricAlarm
+	pages := cloudwatch.NewDescribeAlarmsPaginator(c.api, &cloudwatch.DescribeAlarmsInput{AlarmNames: names})
+	for pages.HasMorePages() {
+		page, err := pages.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe alarms: %w", err)
+		}
+		for _, a := range page.MetricAlarms {
+			alarms = append(alarms, MetricAlarm{
+				AlarmName:   deref(a.AlarmName),
+				StateValue:  string(a.StateValue),
+				StateReason: deref(a.StateReason),
+			})
+		}
+	}
+	return alarms, nil
+}
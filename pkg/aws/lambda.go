@@ -0,0 +1,210 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+// Lambda is a client for the Lambda code, version and alias APIs
+type Lambda struct {
+	*Client
+	api          *lambda.Client
+	PollInterval time.Duration // between checks while a version becomes active
+}
+
+// NewLambda creates a Lambda client
+func NewLambda(client *Client) *Lambda {
+	return &Lambda{Client: client, api: lambda.NewFromConfig(client.Config), PollInterval: 2 * time.Second}
+}
+
+// FunctionConfiguration is the configuration of a function version
+type FunctionConfiguration struct {
+	FunctionName     string
+	FunctionArn      string
+	Version          string
+	CodeSha256       string
+	State            string
+	StateReason      string
+	LastUpdateStatus string
+}
+
+// AliasRoutingConfig sends a share of an alias's traffic to other versions
+type AliasRoutingConfig struct {
+	AdditionalVersionWeights map[string]float64
+}
+
+// Alias is a function alias
+type Alias struct {
+	Name            string
+	FunctionVersion string
+	RevisionID      string
+	RoutingConfig   *AliasRoutingConfig
+}
+
+func functionConfiguration(name, arn, version, sha *string, state types.State, reason *string, update types.LastUpdateStatus) *FunctionConfiguration {
+	return &FunctionConfiguration{
+		FunctionName:     deref(name),
+		FunctionArn:      deref(arn),
+		Version:          deref(version),
+		CodeSha256:       deref(sha),
+		State:            string(state),
+		StateReason:      deref(reason),
+		LastUpdateStatus: string(update),
+	}
+}
+
+func alias(name, version, revision *string, routing *types.AliasRoutingConfiguration) *Alias {
+	a := &Alias{Name: deref(name), FunctionVersion: deref(version), RevisionID: deref(revision)}
+	if routing != nil {
+		a.RoutingConfig = &AliasRoutingConfig{AdditionalVersionWeights: routing.AdditionalVersionWeights}
+	}
+	return a
+}
+
+// GetFunctionConfiguration returns the configuration of a version, or of
+// $LATEST when qualifier is empty
+func (l *Lambda) GetFunctionConfiguration(ctx context.Context, function, qualifier string) (*FunctionConfiguration, error) {
+	out, err := l.api.GetFunctionConfiguration(ctx, &lambda.GetFunctionConfigurationInput{
+		FunctionName: &function,
+		Qualifier:    optional(qualifier),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get configuration of %s: %w", function, err)
+	}
+	return functionConfiguration(out.FunctionName, out.FunctionArn, out.Version, out.CodeSha256, out.State, out.StateReason, out.LastUpdateStatus), nil
+}
+
+// UpdateFunctionCode uploads a zip package as the function's code, publishing
+// a new version when publish is set
+func (l *Lambda) UpdateFunctionCode(ctx context.Context, function string, zip []byte, publish bool) (*FunctionConfiguration, error) {
+	out, err := l.api.UpdateFunctionCode(ctx, &lambda.UpdateFunctionCodeInput{
+		FunctionName: &function,
+		ZipFile:      zip,
+		Publish:      publish,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update code of %s: %w", function, err)
+	}
+	return functionConfiguration(out.FunctionName, out.FunctionArn, out.Version, out.CodeSha256, out.State, out.StateReason, out.LastUpdateStatus), nil
+}
+
+// GetAlias returns an alias
+func (l *Lambda) GetAlias(ctx context.Context, function, name string) (*Alias, error) {
+	out, err := l.api.GetAlias(ctx, &lambda.GetAliasInput{FunctionName: &function, Name: &name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alias %s of %s: %w", name, function, err)
+	}
+	return alias(out.Name, out.FunctionVersion, out.RevisionId, out.RoutingConfig), nil
+}
+
+// UpdateAlias points an alias at a version. A nil routing config clears any
+// weighted routing.
+func (l *Lambda) UpdateAlias(ctx context.Context, function, name, version string, routing *AliasRoutingConfig) (*Alias, error) {
+	weights := map[string]float64{}
+	if routing != nil {
+		weights = routing.AdditionalVersionWeights
+	}
+	out, err := l.api.UpdateAlias(ctx, &lambda.UpdateAliasInput{
+		FunctionName:    &function,
+		Name:            &name,
+		FunctionVersion: &version,
+		RoutingConfig:   &types.AliasRoutingConfiguration{AdditionalVersionWeights: weights},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update alias %s of %s: %w", name, function, err)
+	}
+	return alias(out.Name, out.FunctionVersion, out.RevisionId, out.RoutingConfig), nil
+}
+
+// Invoke calls a version synchronously with payload and returns its response.
+// A function error (an unhandled exception or timeout) is returned as an error.
+func (l *Lambda) Invoke(ctx context.Context, function, qualifier string, payload []byte) ([]byte, error) {
+	out, err := l.api.Invoke(ctx, &lambda.InvokeInput{
+		FunctionName: &function,
+		Qualifier:    optional(qualifier),
+		Payload:      payload,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to invoke %s:%s: %w", function, qualifier, err)
+	}
+	if out.FunctionError != nil {
+		return nil, fmt.Errorf("%s:%s failed with %s: %s", function, qualifier, *out.FunctionError, out.Payload)
+	}
+	return out.Payload, nil
+}
+
+// GetFunctionCode downloads the deployment package of a version
+func (l *Lambda) GetFunctionCode(ctx context.Context, function, qualifier string) ([]byte, error) {
+	out, err := l.api.GetFunction(ctx, &lambda.GetFunctionInput{
+		FunctionName: &function,
+		Qualifier:    optional(qualifier),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get code of %s:%s: %w", function, qualifier, err)
+	}
+	if out.Code == nil || out.Code.Location == nil {
+		return nil, fmt.Errorf("failed to get code of %s:%s: no download location", function, qualifier)
+	}
+
+	// Location is a presigned URL and must not be signed again
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, *out.Code.Location, nil)
+	if err != nil {
+		return nil, err
+	}
+	httpClient := l.HTTP
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download code of %s:%s: %w", function, qualifier, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download code of %s:%s: HTTP %d", function, qualifier, resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// WaitForVersion polls until a version is active and its last update has
+// finished, so it can take traffic
+func (l *Lambda) WaitForVersion(ctx context.Context, function, version string) error {
+	for {
+		cfg, err := l.GetFunctionConfiguration(ctx, function, version)
+		if err != nil {
+			return err
+		}
+		switch {
+		case cfg.State == "Failed" || cfg.LastUpdateStatus == "Failed":
+			return fmt.Errorf("version %s of %s failed to activate: %s", version, function, cfg.StateReason)
+		case (cfg.State == "" || cfg.State == "Active") && cfg.LastUpdateStatus != "InProgress":
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(l.PollInterval):
+		}
+	}
+}
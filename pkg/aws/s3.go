@@ -0,0 +1,98 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// S3 is a client for the S3 object APIs used by static site deploys
+type S3 struct {
+	api *s3.Client
+}
+
+// NewS3 creates an S3 client. Endpoint overrides (AWS_ENDPOINT_URL_S3) use
+// path-style addressing, which is what stand-ins such as MinIO expect.
+func NewS3(client *Client) *S3 {
+	pathStyle := EndpointURL("s3") != ""
+	return &S3{api: s3.NewFromConfig(client.Config, func(o *s3.Options) {
+		o.UsePathStyle = pathStyle
+	})}
+}
+
+// Object is an object in a bucket listing
+type Object struct {
+	Key  string
+	ETag string // unquoted; the hex MD5 of the content for single-part uploads
+	Size int64
+}
+
+// PutObjectInput describes an object to upload
+type PutObjectInput struct {
+	Bucket       string
+	Key          string
+	Body         []byte
+	ContentType  string
+	CacheControl string
+}
+
+// ListObjects returns every object in a bucket
+func (s *S3) ListObjects(ctx context.Context, bucket string) ([]Object, error) {
+	var objects []Object
+	pages := s3.NewListObjectsV2Paginator(s.api, &s3.ListObjectsV2Input{Bucket: &bucket})
+	for pages.HasMorePages() {
+		page, err := pages.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list s3://%s: %w", bucket, err)
+		}
+		for _, c := range page.Contents {
+			size := int64(0)
+			if c.Size != nil {
+				size = *c.Size
+			}
+			objects = append(objects, Object{Key: deref(c.Key), ETag: strings.Trim(deref(c.ETag), `"`), Size: size})
+		}
+	}
+	return objects, nil
+}
+
+// PutObject uploads an object
+func (s *S3) PutObject(ctx context.Context, in PutObjectInput) error {
+	_, err := s.api.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:       &in.Bucket,
+		Key:          &in.Key,
+		Body:         bytes.NewReader(in.Body),
+		ContentType:  optional(in.ContentType),
+		CacheControl: optional(in.CacheControl),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload s3://%s/%s: %w", in.Bucket, in.Key, err)
+	}
+	return nil
+}
+
+// DeleteObject deletes an object
+func (s *S3) DeleteObject(ctx context.Context, bucket, key string) error {
+	_, err := s.api.DeleteObject(ctx, &s3.DeleteObjectInput{Bucket: &bucket, Key: &key})
+	if err != nil {
+		return fmt.Errorf("failed to delete s3://%s/%s: %w", bucket, key, err)
+	}
+	return nil
+}
@@ -18,13 +18,19 @@ import (
 	"bufio"
 	"fmt"
 	"os"
-	"os/exec"
+	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/OplexTech/soloops-cli/pkg/plan"
+	"github.com/OplexTech/soloops-cli/pkg/tf"
 	"github.com/spf13/cobra"
 )
 
-var autoApprove bool
+var (
+	autoApprove bool
+	applyPlan   string
+)
 
 var applyCmd = &cobra.Command{
 	Use:   "apply",
@@ -36,38 +42,59 @@ Requires:
   - Generated Terraform files (run 'soloops generate' first)
   - Cloud credentials configured
 
+With --plan, applies a plan saved by 'soloops preview' exactly as shown,
+refusing if soloops.yaml or the generated files changed since it was made.
+
 Flags:
-  --auto-approve: Skip interactive approval prompt`,
+  --auto-approve: Skip interactive approval prompt
+  --plan: Apply a saved plan file`,
 	RunE: runApply,
 }
 
 func init() {
 	applyCmd.Flags().BoolVar(&autoApprove, "auto-approve", false, "Skip interactive approval prompt")
+	applyCmd.Flags().StringVar(&applyPlan, "plan", "", "Apply a saved plan file (from 'soloops preview')")
 }
 
 func runApply(cmd *cobra.Command, args []string) error {
+	out := cmd.OutOrStdout()
+
 	// Check if infra directory exists
-	if _, err := os.Stat("infra"); os.IsNotExist(err) {
-		return fmt.Errorf("infra/ directory not found. Run 'soloops generate' first")
+	dir := infraDir()
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return fmt.Errorf("%s/ directory not found. Run 'soloops generate' first", dir)
 	}
 
-	// Initialize Terraform if needed
-	tfInit := exec.Command("terraform", "init")
-	tfInit.Dir = "infra"
-	tfInit.Stdout = os.Stdout
-	tfInit.Stderr = os.Stderr
+	var planFile string
+	if applyPlan != "" {
+		meta, err := plan.Verify(applyPlan, configFile, envName, terraformDirs(dir)...)
+		if err != nil {
+			return fmt.Errorf("refusing to apply %s: %w", applyPlan, err)
+		}
+		// Terraform runs in infra/, so the plan path must be absolute
+		if planFile, err = filepath.Abs(applyPlan); err != nil {
+			return fmt.Errorf("invalid plan path: %w", err)
+		}
+		fmt.Fprintf(out, "Applying saved plan %s (%s, created %s)\n", applyPlan, meta.Environment, meta.CreatedAt.Local().Format(time.RFC1123))
+	}
+
+	runner, err := newRunner(cmd)
+	if err != nil {
+		return err
+	}
 
-	fmt.Println("Initializing Terraform...")
-	if err := tfInit.Run(); err != nil {
+	// Initialize Terraform if needed
+	fmt.Fprintln(out, "Initializing Terraform...")
+	if err := runner.Init(cmd.Context()); err != nil {
 		return fmt.Errorf("terraform init failed: %w", err)
 	}
 
 	// Confirm before applying
 	if !autoApprove {
-		fmt.Println("\n⚠️  This will provision real infrastructure and may incur costs.")
-		fmt.Print("Do you want to continue? (yes/no): ")
+		fmt.Fprintln(out, "\n⚠️  This will provision real infrastructure and may incur costs.")
+		fmt.Fprint(out, "Do you want to continue? (yes/no): ")
 
-		reader := bufio.NewReader(os.Stdin)
+		reader := bufio.NewReader(cmd.InOrStdin())
 		response, err := reader.ReadString('\n')
 		if err != nil {
 			return fmt.Errorf("failed to read input: %w", err)
@@ -75,31 +102,20 @@ func runApply(cmd *cobra.Command, args []string) error {
 
 		response = strings.TrimSpace(strings.ToLower(response))
 		if response != "yes" && response != "y" {
-			fmt.Println("Aborted.")
+			fmt.Fprintln(out, "Aborted.")
 			return nil
 		}
 	}
 
 	// Run terraform apply
-	applyArgs := []string{"apply"}
-	if autoApprove {
-		applyArgs = append(applyArgs, "-auto-approve")
-	}
-
-	tfApply := exec.Command("terraform", applyArgs...)
-	tfApply.Dir = "infra"
-	tfApply.Stdout = os.Stdout
-	tfApply.Stderr = os.Stderr
-	tfApply.Stdin = os.Stdin
-
-	fmt.Println("\nApplying infrastructure changes...")
-	if err := tfApply.Run(); err != nil {
+	fmt.Fprintln(out, "\nApplying infrastructure changes...")
+	if err := runner.Apply(cmd.Context(), tf.ApplyOptions{AutoApprove: autoApprove, PlanFile: planFile}); err != nil {
 		return fmt.Errorf("terraform apply failed: %w", err)
 	}
 
-	fmt.Println("\n✓ Infrastructure provisioned successfully")
-	fmt.Println("\nTo view outputs, run: cd infra && terraform output")
-	fmt.Println("To destroy resources, run: soloops destroy")
+	fmt.Fprintln(out, "\n✓ Infrastructure provisioned successfully")
+	fmt.Fprintln(out, "\nTo view outputs, run: soloops outputs")
+	fmt.Fprintln(out, "To destroy resources, run: soloops destroy")
 
 	return nil
 }
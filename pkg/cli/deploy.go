@@ -0,0 +1,309 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/OplexTech/soloops-cli/pkg/aws"
+	"github.com/OplexTech/soloops-cli/pkg/config"
+	"github.com/OplexTech/soloops-cli/pkg/deploy"
+	"github.com/spf13/cobra"
+)
+
+var (
+	deployDir           string
+	deployDryRun        bool
+	deployNoInvalidate  bool
+	deployHealthPath    string
+	deployHealthTimeout time.Duration
+)
+
+// defaultSiteDir is where static sites are deployed from without --dir; the
+// static-site starters scaffold the website there
+const defaultSiteDir = "site"
+
+var deployCmd = &cobra.Command{
+	Use:   "deploy <blueprint>",
+	Short: "Deploy application files to a provisioned blueprint",
+	Long: `Deploys application code to a blueprint that 'soloops apply' created,
+without running Terraform.
+
+For web_api blueprints, the function in --dir (default: the directory named
+after the blueprint, as created by 'soloops init --template') is packaged
+with its dependencies (pip for requirements.txt, npm for package.json) and:
+  - published as a new Lambda version
+  - health checked by invoking that version directly with an API Gateway
+    GET <health path> event; on failure the previous code is restored and
+    the command fails
+  - made live by moving the function's "live" alias to it
+Nothing is published when the live version already runs the same package.
+
+With a release strategy in the blueprint (release: {strategy: canary,
+steps: [10, 50, 100], interval: 5m}), traffic moves to the new version in
+those steps once the new version passes its health check. Each step is held
+for the interval while the generated CloudWatch alarms, which cover the
+alias's weighted traffic, are watched; any alarm rolls the release back.
+blue_green moves all traffic at once and then watches the alarms.
+
+For static_site blueprints, the files in --dir (default ./site, where the
+static-site starters put the website) are synced to
+the site's S3 bucket:
+  - only files whose content changed are uploaded (compared by MD5/ETag)
+  - Content-Type is set from the file extension
+  - HTML, JSON, XML and text files get a short Cache-Control, other assets
+    are cached for a year
+  - objects that no longer exist locally are deleted
+  - the CloudFront cache is invalidated when anything changed
+
+AWS credentials come from the standard AWS chain: environment variables,
+AWS_PROFILE (including SSO, role_arn and credential_process profiles) and
+container or instance roles. AWS_ENDPOINT_URL_<SERVICE> (e.g.
+AWS_ENDPOINT_URL_S3) points a deploy at local stand-ins.
+
+Flags:
+  --dir: Directory to deploy
+  --dry-run: Show what would change without deploying
+  --no-invalidate: Skip the CloudFront invalidation (static_site)
+  --health-path: Path requested from the new web_api version before it takes
+    traffic, "" to skip (default /health)
+  --health-timeout: How long the health check may fail before rolling back
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDeploy,
+}
+
+func init() {
+	deployCmd.Flags().StringVar(&deployDir, "dir", "", "Directory to deploy (default: ./site for static sites, ./<blueprint> for APIs)")
+	deployCmd.Flags().BoolVar(&deployDryRun, "dry-run", false, "Show what would change without deploying")
+	deployCmd.Flags().BoolVar(&deployNoInvalidate, "no-invalidate", false, "Skip the CloudFront invalidation")
+	deployCmd.Flags().StringVar(&deployHealthPath, "health-path", "/health", "Path requested from the new web_api version before it takes traffic (\"\" to skip)")
+	deployCmd.Flags().DurationVar(&deployHealthTimeout, "health-timeout", 30*time.Second, "How long the health check may fail before rolling back")
+}
+
+func runDeploy(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	env, err := cfg.GetEnvironment(targetEnvName(cfg))
+	if err != nil {
+		return err
+	}
+	bp, ok := env.Blueprints[name]
+	if !ok {
+		return fmt.Errorf("blueprint %q not found in environment %s", name, env.Name)
+	}
+
+	dir := deployDir
+	switch bp.Kind() {
+	case config.KindStaticSite:
+		if dir == "" {
+			dir = defaultSiteDir
+		}
+		return deployStaticSite(cmd, env, name, dir)
+	case config.KindWebAPI:
+		if dir == "" {
+			dir = name
+		}
+		return deployWebAPI(cmd, env, name, bp, dir)
+	default:
+		return fmt.Errorf("blueprint %q has type %s; deploy supports %s and %s", name, bp.Kind(), config.KindWebAPI, config.KindStaticSite)
+	}
+}
+
+func deployWebAPI(cmd *cobra.Command, env *config.Environment, name string, bp config.Blueprint, dir string) error {
+	out := cmd.OutOrStdout()
+
+	fmt.Fprintf(out, "Packaging %s (%s)...\n", dir, bp.LambdaRuntimeName())
+	pkg, err := deploy.BuildPackage(deploy.BuildOptions{
+		Dir:          dir,
+		Runtime:      bp.LambdaRuntimeName(),
+		Architecture: bp.LambdaSettings().Architecture,
+		Output:       cmd.ErrOrStderr(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to package %s: %w", dir, err)
+	}
+
+	outputs, err := blueprintOutputs(cmd)
+	if err != nil {
+		return err
+	}
+	function := outputs[name+"_lambda_arn"]
+	if function == "" {
+		return fmt.Errorf("no function found for %s. Run 'soloops apply' first", name)
+	}
+
+	release := &deploy.Release{
+		Function:      function,
+		Alias:         deploy.LiveAlias,
+		Package:       pkg,
+		HealthPath:    deployHealthPath,
+		HealthTimeout: deployHealthTimeout,
+	}
+	if bp.Release != nil {
+		release.Steps = bp.Release.TrafficSteps()
+		release.Interval = bp.Release.IntervalDuration()
+		if alarms := outputs[name+"_release_alarms"]; alarms != "" {
+			release.Alarms = strings.Split(alarms, ",")
+		}
+	}
+
+	if deployDryRun {
+		fmt.Fprintf(out, "Would publish a %d KB package to %s and move alias %s\n", (len(pkg)+1023)/1024, function, release.Alias)
+		if bp.Release != nil {
+			fmt.Fprintf(out, "Release: %s, traffic steps %v, each held for %s\n", bp.Release.Strategy, release.Steps, release.Interval)
+		}
+		fmt.Fprintln(out, "\nDry run: nothing was changed")
+		return nil
+	}
+
+	client, err := aws.NewClient(cmd.Context(), env.Region)
+	if err != nil {
+		return err
+	}
+	release.CloudWatch = aws.NewCloudWatch(client)
+	result, err := release.Run(cmd.Context(), aws.NewLambda(client), func(format string, args ...interface{}) {
+		fmt.Fprintf(out, "  "+format+"\n", args...)
+	})
+	if aws.IsNotFound(err) {
+		return fmt.Errorf("%w\nThe %s alias is created by newer generated files. Run 'soloops generate' and 'soloops apply' first", err, deploy.LiveAlias)
+	}
+	if err != nil {
+		return err
+	}
+
+	if result.Unchanged {
+		fmt.Fprintf(out, "✓ Already up to date (version %s is live)\n", result.Version)
+		return nil
+	}
+	fmt.Fprintf(out, "✓ Version %s of %s is live\n", result.Version, name)
+	return nil
+}
+
+func deployStaticSite(cmd *cobra.Command, env *config.Environment, name, dir string) error {
+	out := cmd.OutOrStdout()
+
+	files, err := deploy.LocalFiles(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("%s is empty; build the site first", dir)
+	}
+
+	outputs, err := blueprintOutputs(cmd)
+	if err != nil {
+		return err
+	}
+	bucket := outputs[name+"_bucket_name"]
+	if bucket == "" {
+		return fmt.Errorf("no bucket found for %s. Run 'soloops apply' first", name)
+	}
+	distribution := outputs[name+"_cloudfront_distribution_id"]
+
+	client, err := aws.NewClient(cmd.Context(), env.Region)
+	if err != nil {
+		return err
+	}
+	s3 := aws.NewS3(client)
+
+	remote, err := s3.ListObjects(cmd.Context(), bucket)
+	if err != nil {
+		return err
+	}
+	sitePlan := deploy.PlanSite(files, remote)
+
+	fmt.Fprintf(out, "Deploying %s to s3://%s (%d to upload, %d to delete, %d unchanged)\n",
+		dir, bucket, len(sitePlan.Upload), len(sitePlan.Delete), sitePlan.Unchanged)
+
+	if deployDryRun {
+		for _, f := range sitePlan.Upload {
+			fmt.Fprintf(out, "  + %s\n", f.Key)
+		}
+		for _, key := range sitePlan.Delete {
+			fmt.Fprintf(out, "  - %s\n", key)
+		}
+		fmt.Fprintln(out, "\nDry run: nothing was changed")
+		return nil
+	}
+
+	if sitePlan.Empty() {
+		fmt.Fprintln(out, "✓ Already up to date")
+		return nil
+	}
+
+	symbols := map[string]string{"upload": "+", "delete": "-"}
+	err = sitePlan.Apply(cmd.Context(), s3, bucket, func(action, key string) {
+		fmt.Fprintf(out, "  %s %s\n", symbols[action], key)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "✓ Synced %d files\n", len(sitePlan.Upload)+len(sitePlan.Delete))
+
+	switch {
+	case deployNoInvalidate:
+	case distribution == "":
+		fmt.Fprintf(cmd.ErrOrStderr(), "⚠️  No CloudFront distribution ID output for %s; skipping invalidation. Run 'soloops generate' and 'soloops apply' to add it.\n", name)
+	default:
+		cf := aws.NewCloudFront(client)
+		id, err := cf.CreateInvalidation(cmd.Context(), distribution, fmt.Sprintf("soloops-%d", time.Now().UnixNano()), []string{"/*"})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "✓ Invalidated CloudFront cache (%s)\n", id)
+	}
+
+	if url := outputs[name+"_cloudfront_url"]; url != "" {
+		fmt.Fprintf(out, "\nLive at: https://%s\n", url)
+	}
+	return nil
+}
+
+// blueprintOutputs reads the string outputs of the applied infrastructure.
+// Placeholder "N/A" values from the generated try() fallbacks are dropped.
+func blueprintOutputs(cmd *cobra.Command) (map[string]string, error) {
+	dir := infraDir()
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil, fmt.Errorf("%s/ directory not found. Run 'soloops generate' first", dir)
+	}
+
+	runner, err := newRunnerWithOutput(cmd, cmd.ErrOrStderr())
+	if err != nil {
+		return nil, err
+	}
+	outputs, err := runner.Output(cmd.Context())
+	if err != nil {
+		return nil, fmt.Errorf("terraform output failed: %w", err)
+	}
+
+	values := map[string]string{}
+	for name, output := range outputs {
+		var s string
+		if err := json.Unmarshal(output.Value, &s); err != nil || s == "N/A" {
+			continue
+		}
+		values[name] = s
+	}
+	return values, nil
+}
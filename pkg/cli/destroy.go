@@ -18,61 +18,203 @@ import (
 	"bufio"
 	"fmt"
 	"os"
-	"os/exec"
 	"strings"
 
+	"github.com/OplexTech/soloops-cli/pkg/config"
+	"github.com/OplexTech/soloops-cli/pkg/plan"
+	"github.com/OplexTech/soloops-cli/pkg/tf"
 	"github.com/spf13/cobra"
 )
 
+var (
+	destroyAutoApprove bool
+	destroyTargets     []string
+)
+
 var destroyCmd = &cobra.Command{
 	Use:   "destroy",
 	Short: "Destroy provisioned infrastructure",
-	Long: `Destroys all infrastructure resources by running 'terraform destroy'.
+	Long: `Destroys infrastructure resources by running 'terraform destroy'.
 
 ⚠️  WARNING: This is a destructive operation that cannot be undone!
 
+Blueprints marked 'protect: true' are never destroyed: SoloOps lists their
+resources and refuses. To remove one, drop 'protect', run 'soloops generate'
+and 'soloops apply', then destroy again.
+
+Production environments (prod, production) must be confirmed by typing the
+environment name.
+
 Requires:
   - Terraform binary installed locally
   - Existing Terraform state (resources must be provisioned first)
-  - Cloud credentials configured`,
+  - Cloud credentials configured
+
+Flags:
+  --target: Destroy only this blueprint (repeatable)
+  --auto-approve: Skip confirmation, e.g. in CI`,
 	RunE: runDestroy,
 }
 
+func init() {
+	destroyCmd.Flags().BoolVar(&destroyAutoApprove, "auto-approve", false, "Skip confirmation")
+	destroyCmd.Flags().StringArrayVar(&destroyTargets, "target", nil, "Destroy only this blueprint (repeatable)")
+}
+
 func runDestroy(cmd *cobra.Command, args []string) error {
+	out := cmd.OutOrStdout()
+
 	// Check if infra directory exists
-	if _, err := os.Stat("infra"); os.IsNotExist(err) {
-		return fmt.Errorf("infra/ directory not found")
+	dir := infraDir()
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return fmt.Errorf("%s/ directory not found", dir)
 	}
 
-	// Confirm destruction
-	fmt.Println("⚠️  WARNING: This will DESTROY all provisioned infrastructure!")
-	fmt.Print("Type 'destroy' to confirm: ")
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	env, err := cfg.GetEnvironment(targetEnvName(cfg))
+	if err != nil {
+		return err
+	}
 
-	reader := bufio.NewReader(os.Stdin)
-	response, err := reader.ReadString('\n')
+	scope := env.BlueprintNames()
+	if len(destroyTargets) > 0 {
+		scope = destroyTargets
+	}
+	for _, name := range scope {
+		if _, ok := env.Blueprints[name]; !ok {
+			return fmt.Errorf("unknown blueprint %q in environment %s", name, env.Name)
+		}
+	}
+
+	runner, err := newRunner(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to read input: %w", err)
+		return err
 	}
 
-	response = strings.TrimSpace(strings.ToLower(response))
-	if response != "destroy" {
-		fmt.Println("Aborted.")
-		return nil
+	fmt.Fprintln(out, "Initializing Terraform...")
+	if err := runner.Init(cmd.Context()); err != nil {
+		return fmt.Errorf("terraform init failed: %w", err)
+	}
+
+	stateJSON, err := runner.Show(cmd.Context(), "")
+	if err != nil {
+		return fmt.Errorf("terraform show failed: %w", err)
+	}
+	resources, err := tf.StateResources(stateJSON)
+	if err != nil {
+		return err
+	}
+
+	owned := map[string][]string{}
+	for _, r := range resources {
+		owner := plan.Owner(r.ModuleAddress, r.Name, env.BlueprintNames())
+		owned[owner] = append(owned[owner], r.Address)
+	}
+
+	if err := checkProtected(env, scope, owned); err != nil {
+		return err
+	}
+
+	var targets []string
+	for _, name := range destroyTargets {
+		if len(owned[name]) == 0 {
+			return fmt.Errorf("blueprint %s has no resources in the Terraform state", name)
+		}
+		targets = append(targets, owned[name]...)
+	}
+
+	if !destroyAutoApprove {
+		confirmed, err := confirmDestroy(cmd, env.Name, targets)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Fprintln(out, "Aborted.")
+			return nil
+		}
 	}
 
 	// Run terraform destroy
-	tfDestroy := exec.Command("terraform", "destroy")
-	tfDestroy.Dir = "infra"
-	tfDestroy.Stdout = os.Stdout
-	tfDestroy.Stderr = os.Stderr
-	tfDestroy.Stdin = os.Stdin
-
-	fmt.Println("\nDestroying infrastructure...")
-	if err := tfDestroy.Run(); err != nil {
+	fmt.Fprintln(out, "\nDestroying infrastructure...")
+	err = runner.Destroy(cmd.Context(), tf.DestroyOptions{
+		AutoApprove: destroyAutoApprove,
+		Targets:     targets,
+	})
+	if err != nil {
 		return fmt.Errorf("terraform destroy failed: %w", err)
 	}
 
-	fmt.Println("\n✓ Infrastructure destroyed successfully")
+	fmt.Fprintln(out, "\n✓ Infrastructure destroyed successfully")
 
 	return nil
 }
+
+// checkProtected refuses to destroy blueprints marked protect: true, listing
+// their resources
+func checkProtected(env *config.Environment, scope []string, owned map[string][]string) error {
+	var lines []string
+	for _, name := range scope {
+		if !env.Blueprints[name].Protect {
+			continue
+		}
+		resources := owned[name]
+		if len(resources) == 0 {
+			resources = []string{"(no resources in state)"}
+		}
+		lines = append(lines, fmt.Sprintf("  %s: %s", name, strings.Join(resources, ", ")))
+	}
+
+	if len(lines) == 0 {
+		return nil
+	}
+	return fmt.Errorf("refusing to destroy protected blueprints:\n%s\nRemove 'protect: true', run 'soloops generate' and 'soloops apply', then destroy again",
+		strings.Join(lines, "\n"))
+}
+
+// confirmDestroy asks the user to type 'destroy', or the environment name
+// for production
+func confirmDestroy(cmd *cobra.Command, envName string, targets []string) (bool, error) {
+	out := cmd.OutOrStdout()
+
+	if len(targets) > 0 {
+		fmt.Fprintf(out, "⚠️  WARNING: This will DESTROY %d resources in %s:\n", len(targets), envName)
+		for _, target := range targets {
+			fmt.Fprintf(out, "  - %s\n", target)
+		}
+	} else {
+		fmt.Fprintf(out, "⚠️  WARNING: This will DESTROY all provisioned infrastructure in %s!\n", envName)
+	}
+
+	want := "destroy"
+	if isProduction(envName) {
+		want = envName
+		fmt.Fprintf(out, "This is a production environment. Type the environment name (%s) to confirm: ", envName)
+	} else {
+		fmt.Fprint(out, "Type 'destroy' to confirm: ")
+	}
+
+	reader := bufio.NewReader(cmd.InOrStdin())
+	response, err := reader.ReadString('\n')
+	if err != nil {
+		return false, fmt.Errorf("failed to read input: %w", err)
+	}
+
+	response = strings.TrimSpace(response)
+	if want == "destroy" {
+		response = strings.ToLower(response)
+	}
+	return response == want, nil
+}
+
+// isProduction reports whether an environment needs the stricter destroy
+// confirmation
+func isProduction(name string) bool {
+	switch strings.ToLower(name) {
+	case "prod", "production":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/OplexTech/soloops-cli/pkg/config"
+	"github.com/OplexTech/soloops-cli/pkg/plan"
+	"github.com/OplexTech/soloops-cli/pkg/tf"
+	"github.com/spf13/cobra"
+)
+
+var driftOutput string
+
+var driftCmd = &cobra.Command{
+	Use:   "drift",
+	Short: "Detect changes made outside SoloOps",
+	Long: `Runs a refresh-only 'terraform plan' to find resources that were changed or
+deleted outside Terraform (e.g. in the cloud console) since the last apply,
+and reports them grouped by blueprint.
+
+Exit codes:
+  0: No drift
+  1: Error
+  2: Drift detected
+
+Flags:
+  --output: Report format (text, json, markdown)`,
+	RunE:          runDrift,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+func init() {
+	driftCmd.Flags().StringVar(&driftOutput, "output", plan.FormatText, "Report format (text, json, markdown)")
+}
+
+func runDrift(cmd *cobra.Command, args []string) error {
+	if !config.Contains(plan.Formats, driftOutput) {
+		return fmt.Errorf("unknown output format %q (supported: text, json, markdown)", driftOutput)
+	}
+
+	// Keep stdout machine-readable; Terraform's output goes to stderr
+	log := cmd.ErrOrStderr()
+
+	dir := infraDir()
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return fmt.Errorf("%s/ directory not found. Run 'soloops generate' first", dir)
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	env, err := cfg.GetEnvironment(targetEnvName(cfg))
+	if err != nil {
+		return err
+	}
+
+	tmpDir, err := os.MkdirTemp("", "soloops-drift-")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+	planFile := filepath.Join(tmpDir, "drift.tfplan")
+
+	runner, err := newRunnerWithOutput(cmd, log)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(log, "Initializing Terraform...")
+	if err := runner.Init(cmd.Context()); err != nil {
+		return fmt.Errorf("terraform init failed: %w", err)
+	}
+
+	fmt.Fprintf(log, "\nRefreshing state for %s...\n", env.Name)
+	if _, err := runner.Plan(cmd.Context(), tf.PlanOptions{Out: planFile, RefreshOnly: true}); err != nil {
+		return fmt.Errorf("terraform plan failed: %w", err)
+	}
+
+	planJSON, err := runner.Show(cmd.Context(), planFile)
+	if err != nil {
+		return fmt.Errorf("terraform show failed: %w", err)
+	}
+
+	drift, err := plan.SummarizeDrift(planJSON, env.BlueprintNames())
+	if err != nil {
+		return err
+	}
+	report, err := drift.RenderDrift(driftOutput, env.Name)
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(cmd.OutOrStdout(), report)
+
+	if total := drift.Totals.Total(); total > 0 {
+		return &ExitError{Code: ExitDrift, Message: fmt.Sprintf("drift detected in %d resources", total)}
+	}
+	return nil
+}
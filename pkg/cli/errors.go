@@ -0,0 +1,35 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+// Exit codes other than the generic failure (1)
+const (
+	// ExitDrift means 'soloops drift' found resources changed outside Terraform
+	ExitDrift = 2
+
+	// ExitStale means 'soloops generate --check' found infra/ out of date
+	ExitStale = 3
+)
+
+// ExitError is returned by commands that report a result through a specific
+// process exit code rather than a failure message
+type ExitError struct {
+	Code    int
+	Message string
+}
+
+func (e *ExitError) Error() string {
+	return e.Message
+}
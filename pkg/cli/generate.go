@@ -15,13 +15,27 @@
 package cli
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"sync"
 
 	"github.com/OplexTech/soloops-cli/pkg/config"
+	"github.com/OplexTech/soloops-cli/pkg/diff"
 	"github.com/OplexTech/soloops-cli/pkg/generator"
 	"github.com/spf13/cobra"
 )
 
+var (
+	generateForce   bool
+	generateCheck   bool
+	generateDryRun  bool
+	generateAllEnvs bool
+)
+
 var generateCmd = &cobra.Command{
 	Use:   "generate",
 	Short: "Generate Terraform infrastructure code",
@@ -33,29 +47,70 @@ Generates:
   - variables.tf - Input variables
   - outputs.tf - Output values
   - budget.tf - Budget alerts
+  - endpoints.tf - VPC endpoints for functions that use a database
 
 Supports blueprints:
   - web_api: Serverless API (Lambda, API Gateway, WAF)
   - static_site: Static website (S3, CloudFront, HTTPS)
-  - database: Managed databases (RDS, Aurora Serverless)`,
-	RunE: runGenerate,
+  - database: Managed databases (RDS, Aurora Serverless)
+
+Files edited by hand since the last generate are not overwritten; the command
+prints what would change and fails unless --force is given. Keep hand-written
+Terraform in overrides/ instead.
+
+Flags:
+  --force: Overwrite generated files that were edited by hand
+  --dry-run: Print a diff of what would change without writing; exits with code 3 if anything would
+  --check: Fail if infra/ differs from what would be generated, without writing (for CI)
+  --all-envs: Generate every environment concurrently, each into infra/<env>/
+    with the modules shared in infra/modules/`,
+	RunE:          runGenerate,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+func init() {
+	generateCmd.Flags().BoolVar(&generateForce, "force", false, "Overwrite generated files edited by hand")
+	generateCmd.Flags().BoolVar(&generateDryRun, "dry-run", false, "Print a diff of what would change without writing")
+	generateCmd.Flags().BoolVar(&generateCheck, "check", false, "Fail if infra/ is out of date, without writing")
+	generateCmd.Flags().BoolVar(&generateAllEnvs, "all-envs", false, "Generate every environment into infra/<env>/")
 }
 
 func runGenerate(cmd *cobra.Command, args []string) error {
-	cfg, err := config.Load(configFile)
+	err := generate(cmd)
+
+	// Out of date results only set the exit code, so state them in one line
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		fmt.Fprintf(cmd.ErrOrStderr(), "✗ %s\n", exitErr.Message)
+	}
+	return err
+}
+
+func generate(cmd *cobra.Command) error {
+	out := cmd.OutOrStdout()
+
+	cfg, err := loadConfig()
 	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
+		return err
 	}
 
 	if err := cfg.Validate(); err != nil {
 		return fmt.Errorf("validation failed: %w", err)
 	}
 
+	if generateAllEnvs {
+		if envName != "" {
+			return fmt.Errorf("--env and --all-envs cannot be combined")
+		}
+		return generateEnvironments(out, cfg)
+	}
+
 	// Determine target environment
 	targetEnv := envName
 	if targetEnv == "" {
 		targetEnv = cfg.Environments[0].Name
-		fmt.Printf("Using default environment: %s\n", targetEnv)
+		fmt.Fprintf(out, "Using default environment: %s\n", targetEnv)
 	}
 
 	env, err := cfg.GetEnvironment(targetEnv)
@@ -63,20 +118,240 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	// Generate Terraform code
+	if err := generateEnv(out, newGenerator(cfg, env, "infra")); err != nil {
+		return err
+	}
+	if generateCheck || generateDryRun {
+		return nil
+	}
+
+	fmt.Fprintf(out, "✓ Generated Terraform files in infra/\n")
+	fmt.Fprintf(out, "  Environment: %s (%s)\n", env.Name, env.Region)
+	fmt.Fprintf(out, "  Budget: $%.2f/month\n", env.BudgetUSD)
+	fmt.Fprintf(out, "  Blueprints: %d\n", len(env.Blueprints))
+	fmt.Fprintln(out, "\nNext steps:")
+	fmt.Fprintln(out, "  1. Review generated files in infra/")
+	fmt.Fprintln(out, "  2. Run 'soloops preview' to see planned changes")
+	fmt.Fprintln(out, "  3. Run 'soloops apply' to provision infrastructure")
+
+	return nil
+}
+
+// generateEnv generates gen's Terraform into its directory, or with --check
+// and --dry-run diffs it against the directory
+func generateEnv(out io.Writer, gen *generator.Generator) error {
+	if !generateCheck && !generateDryRun {
+		gen.Force = generateForce
+		return generateInfra(out, gen)
+	}
+
+	changed, err := diffInfra(out, gen)
+	if err != nil {
+		return err
+	}
+	if changed == 0 {
+		target := "shared modules"
+		if gen.Env != nil {
+			target = "environment " + gen.Env.Name
+		}
+		fmt.Fprintf(out, "✓ %s/ is up to date (%s)\n", gen.Dir, target)
+		return nil
+	}
+	message := fmt.Sprintf("generate would change %d files", changed)
+	if generateCheck {
+		message = fmt.Sprintf("%d generated files are out of date; run 'soloops generate'", changed)
+	}
+	return &ExitError{Code: ExitStale, Message: message}
+}
+
+// generateEnvironments runs generateEnv for every environment concurrently,
+// each into infra/<env> and sharing the modules in infra/modules. Output is
+// buffered per environment and printed in manifest order, followed by a
+// summary; one environment failing does not stop the others.
+func generateEnvironments(out io.Writer, cfg *config.Config) error {
+	type result struct {
+		out bytes.Buffer
+		err error
+	}
+	results := make([]result, len(cfg.Environments))
+
+	var modules result
+	modules.err = generateEnv(&modules.out, newModulesGenerator(cfg))
+	if modules.out.Len() > 0 {
+		fmt.Fprintln(out, "== modules ==")
+		if _, err := modules.out.WriteTo(out); err != nil {
+			return err
+		}
+		fmt.Fprintln(out)
+	}
+
+	var wg sync.WaitGroup
+	for i := range cfg.Environments {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			env := &cfg.Environments[i]
+			results[i].err = generateEnv(&results[i].out, newGenerator(cfg, env, filepath.Join("infra", env.Name)))
+		}(i)
+	}
+	wg.Wait()
+
+	for i, env := range cfg.Environments {
+		if results[i].out.Len() == 0 {
+			continue
+		}
+		fmt.Fprintf(out, "== %s ==\n", env.Name)
+		if _, err := results[i].out.WriteTo(out); err != nil {
+			return err
+		}
+		fmt.Fprintln(out)
+	}
+
+	var failed, stale []string
+	fmt.Fprintln(out, "Summary:")
+	var modulesStale bool
+	var exitErr *ExitError
+	switch err := modules.err; {
+	case err == nil:
+		fmt.Fprintf(out, "  ✓ modules: %s/\n", sharedModulesDir)
+	case errors.As(err, &exitErr) && exitErr.Code == ExitStale:
+		modulesStale = true
+		fmt.Fprintf(out, "  ✗ modules: %s\n", err)
+	default:
+		fmt.Fprintf(out, "  ✗ modules: %s\n", err)
+	}
+	for i, env := range cfg.Environments {
+		dir := filepath.Join("infra", env.Name) + "/"
+		var exitErr *ExitError
+		switch err := results[i].err; {
+		case err == nil:
+			fmt.Fprintf(out, "  ✓ %s: %s (%d blueprints)\n", env.Name, dir, len(env.Blueprints))
+		case errors.As(err, &exitErr) && exitErr.Code == ExitStale:
+			stale = append(stale, env.Name)
+			fmt.Fprintf(out, "  ✗ %s: %s\n", env.Name, err)
+		default:
+			failed = append(failed, env.Name)
+			fmt.Fprintf(out, "  ✗ %s: %s\n", env.Name, err)
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("generation failed for %d of %d environments: %s",
+			len(failed), len(cfg.Environments), strings.Join(failed, ", "))
+	}
+	if modules.err != nil && !modulesStale {
+		return fmt.Errorf("generation failed for %s: %w", sharedModulesDir, modules.err)
+	}
+	if modulesStale && len(stale) == 0 {
+		return &ExitError{Code: ExitStale, Message: fmt.Sprintf("%s/ is out of date", sharedModulesDir)}
+	}
+	if len(stale) > 0 {
+		return &ExitError{Code: ExitStale, Message: fmt.Sprintf("%d of %d environments are out of date: %s",
+			len(stale), len(cfg.Environments), strings.Join(stale, ", "))}
+	}
+	return nil
+}
+
+// newGenerator creates a generator for env writing into dir, with overrides
+// read from next to the manifest. Environments generated into infra/<env>
+// use the shared modules in infra/modules.
+func newGenerator(cfg *config.Config, env *config.Environment, dir string) *generator.Generator {
 	gen := generator.New(cfg, env)
+	gen.Dir = dir
+	gen.Overrides = filepath.Join(filepath.Dir(configFile), generator.OverridesDir)
+	if dir != "infra" {
+		gen.SharedModules = sharedModulesDir
+	}
+	return gen
+}
+
+// newModulesGenerator creates the generator of the modules shared by the
+// environments in infra/<env>
+func newModulesGenerator(cfg *config.Config) *generator.Generator {
+	gen := generator.NewModules(cfg)
+	gen.Overrides = filepath.Join(filepath.Dir(configFile), generator.OverridesDir)
+	return gen
+}
+
+// generateInfra generates gen's Terraform files. Files edited by hand are
+// shown as a diff and only overwritten with gen.Force.
+func generateInfra(out io.Writer, gen *generator.Generator) error {
+	force := gen.Force
+
+	changes, err := gen.Changes()
+	if err != nil {
+		return fmt.Errorf("generation failed: %w", err)
+	}
+	if edited := generator.Edited(changes); len(edited) > 0 {
+		if force {
+			fmt.Fprintf(out, "⚠ Overwriting %d generated files edited by hand:\n", len(edited))
+		} else {
+			fmt.Fprintf(out, "✗ %d generated files were edited by hand; generating would change:\n", len(edited))
+		}
+		printChanges(out, edited)
+	}
+
 	if err := gen.Generate(); err != nil {
 		return fmt.Errorf("generation failed: %w", err)
 	}
+	return nil
+}
 
-	fmt.Printf("✓ Generated Terraform files in infra/\n")
-	fmt.Printf("  Environment: %s (%s)\n", env.Name, env.Region)
-	fmt.Printf("  Budget: $%.2f/month\n", env.BudgetUSD)
-	fmt.Printf("  Blueprints: %d\n", len(env.Blueprints))
-	fmt.Println("\nNext steps:")
-	fmt.Println("  1. Review generated files in infra/")
-	fmt.Println("  2. Run 'soloops preview' to see planned changes")
-	fmt.Println("  3. Run 'soloops apply' to provision infrastructure")
+// diffInfra generates into memory and prints a unified diff against gen's
+// directory on disk, returning the number of files that would change. Files
+// edited by hand are included and flagged.
+func diffInfra(out io.Writer, gen *generator.Generator) (int, error) {
+	changes, err := gen.Changes()
+	if err != nil {
+		return 0, fmt.Errorf("generation failed: %w", err)
+	}
+	edited := map[string]bool{}
+	for _, change := range generator.Edited(changes) {
+		edited[change.Path] = true
+	}
 
-	return nil
+	overlay := generator.NewOverlay(generator.OS)
+	gen.FS = overlay
+	gen.Force = true
+	if err := gen.Generate(); err != nil {
+		return 0, fmt.Errorf("generation failed: %w", err)
+	}
+
+	changed := 0
+	for _, path := range overlay.Paths() {
+		current, _ := overlay.Base.ReadFile(path)
+		content, _ := overlay.ReadFile(path)
+		if bytes.Equal(current, content) {
+			continue
+		}
+		changed++
+		if edited[path] {
+			fmt.Fprintf(out, "# %s was edited by hand; generate needs --force to overwrite it\n", path)
+		}
+		fmt.Fprint(out, diff.Unified(path, path+" (generated)", string(current), string(content)))
+	}
+	return changed, nil
+}
+
+// printChanges prints a unified diff from each file on disk to its generated
+// content
+func printChanges(out io.Writer, changes []generator.Change) {
+	for _, change := range changes {
+		fmt.Fprint(out, diff.Unified(change.Path, change.Path+" (generated)", string(change.Current), string(change.Content)))
+	}
+}
+
+// infraUpToDate reports whether the generators would change nothing: the
+// manifest, overrides and generator all produce the files on disk
+func infraUpToDate(gens ...*generator.Generator) (bool, error) {
+	for _, gen := range gens {
+		changes, err := gen.Changes()
+		if err != nil {
+			return false, fmt.Errorf("generation failed: %w", err)
+		}
+		if len(changes) > 0 {
+			return false, nil
+		}
+	}
+	return true, nil
 }
@@ -0,0 +1,130 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/OplexTech/soloops-cli/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+var graphFormat string
+
+// graphFormats lists the formats supported by 'soloops graph'
+var graphFormats = []string{"dot", "mermaid"}
+
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Show the dependencies between an environment's blueprints",
+	Long: `Prints the blueprints of an environment and the blueprints each one
+depends on through uses or api_origin, in dependency order.
+
+Formats:
+  - dot: Graphviz, e.g. 'soloops graph | dot -Tsvg > graph.svg' (default)
+  - mermaid: A Mermaid flowchart, for Markdown files and pull requests
+
+Flags:
+  --format: Output format (dot, mermaid)`,
+	RunE: runGraph,
+}
+
+func init() {
+	graphCmd.Flags().StringVar(&graphFormat, "format", "dot", "Output format (dot, mermaid)")
+}
+
+func runGraph(cmd *cobra.Command, args []string) error {
+	if !config.Contains(graphFormats, graphFormat) {
+		return fmt.Errorf("unknown format %q (supported: %s)", graphFormat, strings.Join(graphFormats, ", "))
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+	env, err := cfg.GetEnvironment(targetEnvName(cfg))
+	if err != nil {
+		return err
+	}
+	order, err := env.Order()
+	if err != nil {
+		return err
+	}
+
+	if graphFormat == "mermaid" {
+		writeMermaidGraph(cmd.OutOrStdout(), env, order)
+	} else {
+		writeDotGraph(cmd.OutOrStdout(), cfg.Project+"-"+env.Name, env, order)
+	}
+	return nil
+}
+
+// graphLabel describes a blueprint by its type, or its engine for databases
+func graphLabel(bp config.Blueprint) string {
+	if bp.Kind() == config.KindDatabase && bp.DBType != "" {
+		return bp.DBType
+	}
+	return bp.Kind()
+}
+
+func writeDotGraph(out io.Writer, title string, env *config.Environment, order []string) {
+	fmt.Fprintf(out, "digraph %q {\n", title)
+	fmt.Fprintln(out, "  rankdir=LR;")
+	fmt.Fprintln(out, "  node [shape=box];")
+	fmt.Fprintln(out)
+	for _, name := range order {
+		bp := env.Blueprints[name]
+		shape := ""
+		if bp.Kind() == config.KindDatabase {
+			shape = ", shape=cylinder"
+		}
+		fmt.Fprintf(out, "  %q [label=%q%s];\n", name, name+"\n"+graphLabel(bp), shape)
+	}
+
+	edges := false
+	for _, name := range order {
+		for _, dep := range env.Dependencies(name) {
+			if !edges {
+				fmt.Fprintln(out)
+				edges = true
+			}
+			fmt.Fprintf(out, "  %q -> %q;\n", name, dep)
+		}
+	}
+	fmt.Fprintln(out, "}")
+}
+
+func writeMermaidGraph(out io.Writer, env *config.Environment, order []string) {
+	fmt.Fprintln(out, "flowchart LR")
+	for _, name := range order {
+		bp := env.Blueprints[name]
+		label := fmt.Sprintf("%q", name+" ("+graphLabel(bp)+")")
+		if bp.Kind() == config.KindDatabase {
+			fmt.Fprintf(out, "  %s[(%s)]\n", name, label)
+		} else {
+			fmt.Fprintf(out, "  %s[%s]\n", name, label)
+		}
+	}
+	for _, name := range order {
+		for _, dep := range env.Dependencies(name) {
+			fmt.Fprintf(out, "  %s --> %s\n", name, dep)
+		}
+	}
+}
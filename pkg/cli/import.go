@@ -0,0 +1,163 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/OplexTech/soloops-cli/pkg/importer"
+	"github.com/OplexTech/soloops-cli/pkg/wizard"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	importFromState string
+	importStrategy  string
+	importProject   string
+	importBudget    float64
+	importForce     bool
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Propose a soloops.yaml for existing Terraform-managed resources",
+	Long: `Reads a Terraform state file, recognises resource groups that match SoloOps
+blueprints and writes a proposed soloops.yaml plus infra/imports.tf, so the
+next 'soloops apply' adopts the existing resources instead of recreating them.
+
+Recognised groups:
+  - web_api: Lambda function + IAM role + API Gateway HTTP API
+  - static_site: S3 bucket + CloudFront distribution
+
+Strategies:
+  - import: import {} blocks, for starting a fresh SoloOps state (default)
+  - moved: moved {} blocks, for reusing the existing state file
+
+Flags:
+  --from-state: Terraform state file to read (required)
+  --strategy: import or moved
+  --project: Project name for the manifest
+  --budget: Monthly budget in USD
+  --force: Overwrite existing files`,
+	RunE: runImport,
+}
+
+func init() {
+	importCmd.Flags().StringVar(&importFromState, "from-state", "", "Terraform state file to read")
+	importCmd.Flags().StringVar(&importStrategy, "strategy", importer.StrategyImport, "Adoption strategy (import, moved)")
+	importCmd.Flags().StringVar(&importProject, "project", "", "Project name for the manifest")
+	importCmd.Flags().Float64Var(&importBudget, "budget", 0, "Monthly budget in USD")
+	importCmd.Flags().BoolVar(&importForce, "force", false, "Overwrite existing files")
+	_ = importCmd.MarkFlagRequired("from-state")
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	importsFile := filepath.Join("infra", "imports.tf")
+	if !importForce {
+		for _, path := range []string{configFile, importsFile} {
+			if _, err := os.Stat(path); err == nil {
+				return fmt.Errorf("file already exists: %s (use --force to overwrite)", path)
+			}
+		}
+	}
+
+	state, err := importer.LoadState(importFromState)
+	if err != nil {
+		return err
+	}
+
+	proposal := importer.Analyze(state)
+	if len(proposal.Groups) == 0 {
+		return fmt.Errorf("no resources in %s match a SoloOps blueprint", importFromState)
+	}
+
+	defaults := wizard.Defaults()
+	project := importProject
+	if project == "" {
+		project = defaults.Project
+	}
+	env := envName
+	if env == "" {
+		env = defaults.Environments[0].Name
+	}
+	budget := importBudget
+	if budget == 0 {
+		budget = wizard.DefaultBudget(env)
+	}
+
+	cfg := proposal.Manifest(project, env, budget)
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("proposed manifest is invalid: %w", err)
+	}
+
+	imports, err := proposal.Render(importStrategy, importFromState)
+	if err != nil {
+		return err
+	}
+
+	var manifest bytes.Buffer
+	manifest.WriteString(fmt.Sprintf("# Proposed by 'soloops import' from %s\n", importFromState))
+	enc := yaml.NewEncoder(&manifest)
+	enc.SetIndent(2)
+	if err := enc.Encode(cfg); err != nil {
+		return fmt.Errorf("failed to render manifest: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("failed to render manifest: %w", err)
+	}
+
+	if err := os.WriteFile(configFile, manifest.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	if err := os.MkdirAll("infra", 0755); err != nil {
+		return fmt.Errorf("failed to create infra directory: %w", err)
+	}
+	if err := os.WriteFile(importsFile, []byte(imports), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", importsFile, err)
+	}
+
+	fmt.Printf("✓ Created %s\n", configFile)
+	fmt.Printf("✓ Created %s (%s strategy)\n", importsFile, importStrategy)
+	for _, g := range proposal.Groups {
+		fmt.Printf("  %s (%s): %d resources\n", g.Blueprint, g.Kind, len(g.Adoptions))
+	}
+
+	if len(proposal.Unmatched) > 0 {
+		fmt.Printf("\nNot recognised (keep managing these outside SoloOps):\n")
+		for _, address := range proposal.Unmatched {
+			fmt.Printf("  %s\n", address)
+		}
+	}
+
+	if mismatches := proposal.NameMismatches(project, env); len(mismatches) > 0 {
+		fmt.Printf("\n⚠ Physical names differ from what SoloOps generates; these resources would be replaced:\n")
+		for _, m := range mismatches {
+			fmt.Printf("  %s\n", m)
+		}
+		fmt.Println("  Adjust --project/--env or rename the blueprints before applying")
+	}
+
+	fmt.Println("\nNext steps:")
+	fmt.Println("  1. Review soloops.yaml and infra/imports.tf")
+	fmt.Println("  2. Run 'soloops generate' to create Terraform files")
+	fmt.Println("  3. Run 'soloops preview' and confirm no resources are replaced")
+	fmt.Println("  4. Run 'soloops apply' to adopt the existing resources")
+
+	return nil
+}
@@ -17,34 +17,120 @@ package cli
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/OplexTech/soloops-cli/pkg/config"
+	"github.com/OplexTech/soloops-cli/pkg/starter"
+	"github.com/OplexTech/soloops-cli/pkg/wizard"
 	"github.com/spf13/cobra"
 )
 
+var (
+	initProject    string
+	initCloud      string
+	initRegion     string
+	initBudget     float64
+	initBlueprints []string
+	initNoInput    bool
+	initForce      bool
+	initTemplate   string
+	initRepos      []string
+	initList       bool
+)
+
 var initCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Initialize a new SoloOps project",
-	Long: `Creates a starter soloops.yaml manifest with sensible defaults.
-
-The generated manifest includes:
-  - Project configuration
-  - One production environment
-  - Budget settings
-  - Sample blueprints (web API, static site)
-  - Security policies`,
+	Long: `Creates a starter soloops.yaml manifest.
+
+When run in a terminal without flags, an interactive wizard asks for the
+project name, cloud, environments, regions, budgets and blueprints,
+validating each answer as it goes.
+
+For scripting, pass the answers as flags instead (anything omitted uses the
+defaults):
+
+  soloops init --project shop --cloud gcp --env dev,prod \
+    --blueprint web_api:python3.12 --blueprint static_site:shop.example.com
+
+Blueprints are written as [name=]type[:option], where option is the runtime
+for web_api, the domain for static_site and the engine for database.
+
+Project starters lay down application code next to a matching manifest:
+
+  soloops init --template serverless-api-python --project shop
+
+Starters are bundled with the CLI; --template-repo adds a local directory of
+third-party starters (one subdirectory with a starter.yaml per starter).
+
+Flags:
+  --template: Scaffold a project starter
+  --template-repo: Directory of additional starters (repeatable)
+  --list-templates: List available starters
+  --no-input: Never prompt; use flags and defaults
+  --force: Overwrite an existing manifest`,
 	RunE: runInit,
 }
 
+func init() {
+	initCmd.Flags().StringVar(&initProject, "project", "", "Project name")
+	initCmd.Flags().StringVar(&initCloud, "cloud", "", "Cloud provider (aws, gcp, azure)")
+	initCmd.Flags().StringVar(&initRegion, "region", "", "Region for every environment")
+	initCmd.Flags().Float64Var(&initBudget, "budget", 0, "Monthly budget in USD for every environment")
+	initCmd.Flags().StringArrayVar(&initBlueprints, "blueprint", nil, "Blueprint as [name=]type[:option] (repeatable)")
+	initCmd.Flags().BoolVar(&initNoInput, "no-input", false, "Never prompt; use flags and defaults")
+	initCmd.Flags().BoolVar(&initForce, "force", false, "Overwrite an existing manifest")
+	initCmd.Flags().StringVar(&initTemplate, "template", "", "Project starter to scaffold")
+	initCmd.Flags().StringArrayVar(&initRepos, "template-repo", nil, "Directory of additional starters (repeatable)")
+	initCmd.Flags().BoolVar(&initList, "list-templates", false, "List available starters")
+}
+
 func runInit(cmd *cobra.Command, args []string) error {
+	if initList {
+		return listTemplates()
+	}
+
 	// Check if file already exists
-	if _, err := os.Stat(configFile); err == nil {
-		return fmt.Errorf("file already exists: %s (use --file to specify a different path)", configFile)
+	if _, err := os.Stat(configFile); err == nil && !initForce {
+		return fmt.Errorf("file already exists: %s (use --file to specify a different path, or --force)", configFile)
 	}
 
-	// Write the default template
-	template := config.DefaultTemplate()
-	if err := os.WriteFile(configFile, []byte(template), 0644); err != nil {
+	var tmpl *starter.Starter
+	if initTemplate != "" {
+		var err error
+		if tmpl, err = starter.Find(initTemplate, initRepos); err != nil {
+			return err
+		}
+	}
+
+	answers, content, err := initManifest(cmd, tmpl)
+	if err != nil {
+		return err
+	}
+
+	if tmpl != nil {
+		files, err := tmpl.Render(starter.Data{Project: answers.Project})
+		if err != nil {
+			return err
+		}
+
+		// A starter may ship its own manifest
+		if manifest, ok := files[starter.ManifestFile]; ok {
+			content = manifest
+			delete(files, starter.ManifestFile)
+		}
+
+		written, err := starter.WriteFiles(".", files, initForce)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✓ Scaffolded %s (%d files)\n", tmpl.Name, len(written))
+		for _, path := range written {
+			fmt.Printf("  %s\n", path)
+		}
+	}
+
+	if err := os.WriteFile(configFile, content, 0644); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
 
@@ -58,3 +144,119 @@ func runInit(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// initManifest returns the answers and manifest content: the wizard's
+// answers when interactive, the flags when any were given, or the default
+// template
+func initManifest(cmd *cobra.Command, tmpl *starter.Starter) (wizard.Answers, []byte, error) {
+	flagsGiven := envName != "" || initProject != "" || initCloud != "" || initRegion != "" ||
+		initBudget != 0 || len(initBlueprints) > 0
+
+	if !flagsGiven && tmpl == nil && (initNoInput || !isTerminal(os.Stdin)) {
+		return wizard.Defaults(), []byte(config.DefaultTemplate()), nil
+	}
+
+	answers, err := answersFromFlags(tmpl)
+	if err != nil {
+		return answers, nil, err
+	}
+
+	if !flagsGiven && !initNoInput && isTerminal(os.Stdin) {
+		answers, err = wizard.Run(wizard.NewPrompter(cmd.InOrStdin(), cmd.OutOrStdout()), answers)
+		if err != nil {
+			return answers, nil, err
+		}
+		fmt.Println()
+	}
+
+	if err := answers.Validate(); err != nil {
+		return answers, nil, fmt.Errorf("invalid answers: %w", err)
+	}
+
+	content, err := answers.Render()
+	return answers, content, err
+}
+
+// answersFromFlags overlays the init flags (and the starter's blueprints) on
+// the wizard defaults
+func answersFromFlags(tmpl *starter.Starter) (wizard.Answers, error) {
+	answers := wizard.Defaults()
+
+	if tmpl != nil {
+		answers.Blueprints = nil
+		for _, value := range tmpl.Blueprints {
+			spec, err := wizard.ParseBlueprintSpec(value)
+			if err != nil {
+				return answers, fmt.Errorf("template %s: %w", tmpl.Name, err)
+			}
+			answers.Blueprints = append(answers.Blueprints, spec)
+		}
+	}
+
+	if initProject != "" {
+		answers.Project = initProject
+	}
+	if initCloud != "" {
+		answers.Cloud = initCloud
+	}
+
+	envNames := []string{answers.Environments[0].Name}
+	if envName != "" {
+		envNames = strings.Split(envName, ",")
+	}
+
+	answers.Environments = nil
+	for _, name := range envNames {
+		env := wizard.EnvironmentAnswer{
+			Name:      strings.TrimSpace(name),
+			Region:    wizard.DefaultRegion(answers.Cloud),
+			BudgetUSD: wizard.DefaultBudget(strings.TrimSpace(name)),
+		}
+		if initRegion != "" {
+			env.Region = initRegion
+		}
+		if initBudget != 0 {
+			env.BudgetUSD = initBudget
+		}
+		answers.Environments = append(answers.Environments, env)
+	}
+
+	if len(initBlueprints) > 0 {
+		answers.Blueprints = nil
+		for _, value := range initBlueprints {
+			spec, err := wizard.ParseBlueprintSpec(value)
+			if err != nil {
+				return answers, err
+			}
+			answers.Blueprints = append(answers.Blueprints, spec)
+		}
+	}
+
+	return answers, nil
+}
+
+func listTemplates() error {
+	starters, err := starter.List(initRepos)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Available templates:")
+	for _, s := range starters {
+		fmt.Printf("  %-24s %s\n", s.Name, s.Description)
+	}
+	return nil
+}
+
+// isTerminal reports whether f is an interactive terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return false
+	}
+	// /dev/null is a character device too
+	if null, err := os.Stat(os.DevNull); err == nil && os.SameFile(info, null) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/OplexTech/soloops-cli/pkg/config"
+	"github.com/OplexTech/soloops-cli/pkg/diff"
+	"github.com/spf13/cobra"
+)
+
+var migrateDryRun bool
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Upgrade soloops.yaml to the latest schema version",
+	Long: `Rewrites the manifest to the latest apiVersion.
+
+Older manifests keep working (they are upgraded in memory when loaded), but
+migrating makes the file explicit about its schema. Comments and key order
+are preserved.
+
+Flags:
+  --dry-run: Print a diff of the changes without writing the file`,
+	RunE: runMigrate,
+}
+
+func init() {
+	migrateCmd.Flags().BoolVar(&migrateDryRun, "dry-run", false, "Print a diff without writing the file")
+}
+
+func runMigrate(cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	migrated, result, err := config.MigrateBytes(data)
+	if err != nil {
+		return fmt.Errorf("migration failed: %w", err)
+	}
+
+	if !result.Changed {
+		fmt.Printf("✓ %s is already at %s\n", configFile, result.To)
+		return nil
+	}
+
+	if migrateDryRun {
+		fmt.Print(diff.Unified(configFile, configFile+" (migrated)", string(data), string(migrated)))
+		fmt.Printf("\nWould migrate %s from %s to %s\n", configFile, result.From, result.To)
+		return nil
+	}
+
+	info, err := os.Stat(configFile)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	if err := os.WriteFile(configFile, migrated, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	fmt.Printf("✓ Migrated %s from %s to %s\n", configFile, result.From, result.To)
+	fmt.Println("\nRun 'soloops validate' to check the result")
+
+	return nil
+}
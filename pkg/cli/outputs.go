@@ -0,0 +1,215 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/OplexTech/soloops-cli/pkg/config"
+	"github.com/OplexTech/soloops-cli/pkg/plan"
+	"github.com/OplexTech/soloops-cli/pkg/tf"
+	"github.com/spf13/cobra"
+)
+
+var (
+	outputsFormat        string
+	outputsShowSensitive bool
+)
+
+// outputFormats lists the formats supported by 'soloops outputs'
+var outputFormats = []string{"text", "json", "env", "dotenv"}
+
+var outputsCmd = &cobra.Command{
+	Use:   "outputs",
+	Short: "Show Terraform outputs grouped by blueprint",
+	Long: `Reads the Terraform outputs of an applied environment and shows them
+grouped by blueprint.
+
+Formats:
+  - text: Human-readable, grouped by blueprint (default)
+  - json: A flat JSON object of output names to values
+  - env: 'export NAME=value' lines, for eval in shell scripts
+  - dotenv: NAME=value lines, for .env files
+
+Variable names are the upper-cased output names, e.g. API_API_URL for the
+api_url output of the 'api' blueprint. Sensitive outputs are hidden unless
+--show-sensitive is set.
+
+Flags:
+  --format: Output format (text, json, env, dotenv)
+  --show-sensitive: Include sensitive values`,
+	RunE: runOutputs,
+}
+
+func init() {
+	outputsCmd.Flags().StringVar(&outputsFormat, "format", "text", "Output format (text, json, env, dotenv)")
+	outputsCmd.Flags().BoolVar(&outputsShowSensitive, "show-sensitive", false, "Include sensitive values")
+}
+
+func runOutputs(cmd *cobra.Command, args []string) error {
+	if !config.Contains(outputFormats, outputsFormat) {
+		return fmt.Errorf("unknown format %q (supported: %s)", outputsFormat, strings.Join(outputFormats, ", "))
+	}
+
+	dir := infraDir()
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return fmt.Errorf("%s/ directory not found. Run 'soloops generate' first", dir)
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	env, err := cfg.GetEnvironment(targetEnvName(cfg))
+	if err != nil {
+		return err
+	}
+
+	runner, err := newRunnerWithOutput(cmd, cmd.ErrOrStderr())
+	if err != nil {
+		return err
+	}
+	outputs, err := runner.Output(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("terraform output failed: %w", err)
+	}
+	if len(outputs) == 0 {
+		return fmt.Errorf("no outputs found. Run 'soloops apply' first")
+	}
+
+	values, hidden := outputValues(outputs)
+	if hidden > 0 && outputsFormat != "text" {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Note: %d sensitive outputs hidden (use --show-sensitive)\n", hidden)
+	}
+
+	out := cmd.OutOrStdout()
+	switch outputsFormat {
+	case "json":
+		data, err := json.MarshalIndent(values, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(data))
+	case "env", "dotenv":
+		writeEnvOutputs(out, values, outputsFormat == "env")
+	default:
+		writeTextOutputs(out, env, outputs, values)
+	}
+
+	return nil
+}
+
+// outputValues converts outputs to display values: strings as-is, other
+// types as JSON. Sensitive outputs are dropped unless --show-sensitive.
+func outputValues(outputs map[string]tf.Output) (map[string]string, int) {
+	values := map[string]string{}
+	hidden := 0
+	for name, output := range outputs {
+		if output.Sensitive && !outputsShowSensitive {
+			hidden++
+			continue
+		}
+		var s string
+		if err := json.Unmarshal(output.Value, &s); err == nil {
+			values[name] = s
+		} else {
+			values[name] = string(output.Value)
+		}
+	}
+	return values, hidden
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func writeTextOutputs(out io.Writer, env *config.Environment, outputs map[string]tf.Output, values map[string]string) {
+	groups := map[string][]string{}
+	names := make([]string, 0, len(outputs))
+	for name := range outputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		owner := plan.Owner("", name, env.BlueprintNames())
+		groups[owner] = append(groups[owner], name)
+	}
+
+	order := append(env.BlueprintNames(), plan.SharedGroup)
+	for _, group := range order {
+		if len(groups[group]) == 0 {
+			continue
+		}
+		fmt.Fprintf(out, "%s:\n", group)
+
+		width := 0
+		for _, name := range groups[group] {
+			if len(name) > width {
+				width = len(name)
+			}
+		}
+		for _, name := range groups[group] {
+			value, ok := values[name]
+			if !ok {
+				value = "(sensitive)"
+			}
+			fmt.Fprintf(out, "  %-*s  %s\n", width, name, value)
+		}
+	}
+}
+
+var nonEnvChars = regexp.MustCompile(`[^A-Z0-9_]+`)
+
+// envVarName converts an output name into an environment variable name
+func envVarName(output string) string {
+	return nonEnvChars.ReplaceAllString(strings.ToUpper(output), "_")
+}
+
+func writeEnvOutputs(out io.Writer, values map[string]string, export bool) {
+	for _, name := range sortedKeys(values) {
+		value := values[name]
+		if export {
+			fmt.Fprintf(out, "export %s=%s\n", envVarName(name), shellQuote(value))
+			continue
+		}
+		fmt.Fprintf(out, "%s=%s\n", envVarName(name), dotenvQuote(value))
+	}
+}
+
+// shellQuote single-quotes a value for POSIX shells
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// dotenvQuote double-quotes values that contain spaces, quotes or other
+// characters dotenv parsers treat specially
+func dotenvQuote(value string) string {
+	if value != "" && !strings.ContainsAny(value, " \t\n\"'#$\\=`") {
+		return value
+	}
+	value = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "$", `\$`).Replace(value)
+	return `"` + value + `"`
+}
@@ -16,64 +16,181 @@ package cli
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"time"
 
+	"github.com/OplexTech/soloops-cli/pkg/config"
+	"github.com/OplexTech/soloops-cli/pkg/plan"
+	"github.com/OplexTech/soloops-cli/pkg/tf"
 	"github.com/spf13/cobra"
 )
 
+var (
+	previewOut    string
+	previewOutput string
+)
+
 var previewCmd = &cobra.Command{
 	Use:   "preview",
 	Short: "Preview infrastructure changes",
 	Long: `Runs 'terraform plan' to show what changes will be made.
 
+The plan is saved (with its JSON rendering and a hash of soloops.yaml and the
+generated files) so 'soloops apply --plan' applies exactly what was shown.
+
 Requires:
   - Terraform binary installed locally
   - Generated Terraform files (run 'soloops generate' first)
   - Cloud credentials configured (AWS_PROFILE, GOOGLE_CREDENTIALS, etc.)
 
+A summary grouped by blueprint follows the plan, highlighting replaced or
+destroyed resources and changes to data stores. With --output json or
+markdown, only the summary is written to stdout (Terraform's output goes to
+stderr), ready for posting to a pull request.
+
 Optional:
-  - Install 'infracost' for cost estimates`,
+  - Install 'infracost' for cost estimates
+
+Flags:
+  --out: Where to save the plan (default: infra/soloops.tfplan)
+  --output: Summary format (text, json, markdown)`,
 	RunE: runPreview,
 }
 
+func init() {
+	previewCmd.Flags().StringVar(&previewOut, "out", plan.DefaultFile, "Where to save the plan")
+	previewCmd.Flags().StringVar(&previewOutput, "output", plan.FormatText, "Summary format (text, json, markdown)")
+}
+
 func runPreview(cmd *cobra.Command, args []string) error {
+	if !config.Contains(plan.Formats, previewOutput) {
+		return fmt.Errorf("unknown output format %q (supported: text, json, markdown)", previewOutput)
+	}
+
+	// Keep stdout machine-readable for json and markdown summaries
+	out := cmd.OutOrStdout()
+	if previewOutput != plan.FormatText {
+		out = cmd.ErrOrStderr()
+	}
+
 	// Check if infra directory exists
-	if _, err := os.Stat("infra"); os.IsNotExist(err) {
-		return fmt.Errorf("infra/ directory not found. Run 'soloops generate' first")
+	dir := infraDir()
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return fmt.Errorf("%s/ directory not found. Run 'soloops generate' first", dir)
 	}
 
-	// Initialize Terraform if needed
-	tfInit := exec.Command("terraform", "init")
-	tfInit.Dir = "infra"
-	tfInit.Stdout = os.Stdout
-	tfInit.Stderr = os.Stderr
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
 
-	fmt.Println("Initializing Terraform...")
-	if err := tfInit.Run(); err != nil {
-		return fmt.Errorf("terraform init failed: %w", err)
+	runner, err := newRunnerWithOutput(cmd, out)
+	if err != nil {
+		return err
 	}
 
-	// Run terraform plan
-	tfPlan := exec.Command("terraform", "plan")
-	tfPlan.Dir = "infra"
-	tfPlan.Stdout = os.Stdout
-	tfPlan.Stderr = os.Stderr
+	saved, err := savePlan(cmd, runner, cfg, targetEnvName(cfg), previewOut, out)
+	if err != nil {
+		return err
+	}
+	rendered, err := saved.Summary.Render(previewOutput)
+	if err != nil {
+		return err
+	}
 
-	fmt.Println("\nRunning terraform plan...")
-	if err := tfPlan.Run(); err != nil {
-		return fmt.Errorf("terraform plan failed: %w", err)
+	if !saved.Changes {
+		fmt.Fprintln(out, "\n✓ No changes. Infrastructure matches the configuration.")
+	}
+	fmt.Fprintf(out, "\n✓ Saved plan to %s (JSON: %s)\n", previewOut, plan.JSONFile(previewOut))
+	fmt.Fprintf(out, "  To apply exactly this plan, run: soloops apply --plan %s\n", previewOut)
+
+	if previewOutput == plan.FormatText {
+		fmt.Fprintf(out, "\n%s", rendered)
+	} else {
+		fmt.Fprint(cmd.OutOrStdout(), rendered)
 	}
 
 	// Try to run infracost if available
 	if _, err := exec.LookPath("infracost"); err == nil {
-		fmt.Println("\nGenerating cost estimate...")
+		fmt.Fprintln(out, "\nGenerating cost estimate...")
 		costCmd := exec.Command("infracost", "breakdown", "--path", ".")
-		costCmd.Dir = "infra"
-		costCmd.Stdout = os.Stdout
-		costCmd.Stderr = os.Stderr
+		costCmd.Dir = dir
+		costCmd.Stdout = out
+		costCmd.Stderr = cmd.ErrOrStderr()
 		_ = costCmd.Run() // Don't fail if infracost errors
 	}
 
 	return nil
 }
+
+// savedPlan is a plan written by savePlan
+type savedPlan struct {
+	File    string // absolute path
+	Changes bool
+	Summary *plan.Summary
+}
+
+// savePlan runs init and plan, saving the plan with its JSON rendering and
+// metadata for 'apply --plan'
+func savePlan(cmd *cobra.Command, runner tf.Runner, cfg *config.Config, environment, path string, out io.Writer) (*savedPlan, error) {
+	env, err := cfg.GetEnvironment(environment)
+	if err != nil {
+		return nil, err
+	}
+
+	// Hash the inputs before planning, so edits made while terraform runs
+	// invalidate the plan
+	hash, err := plan.Fingerprint(configFile, environment, terraformDirs(infraDir())...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Terraform runs in infra/, so the plan path must be absolute
+	planFile, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid plan path: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(planFile), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create plan directory: %w", err)
+	}
+
+	// Initialize Terraform if needed
+	fmt.Fprintln(out, "Initializing Terraform...")
+	if err := runner.Init(cmd.Context()); err != nil {
+		return nil, fmt.Errorf("terraform init failed: %w", err)
+	}
+
+	// Run terraform plan
+	fmt.Fprintln(out, "\nRunning terraform plan...")
+	changes, err := runner.Plan(cmd.Context(), tf.PlanOptions{Out: planFile})
+	if err != nil {
+		return nil, fmt.Errorf("terraform plan failed: %w", err)
+	}
+
+	planJSON, err := runner.Show(cmd.Context(), planFile)
+	if err != nil {
+		return nil, fmt.Errorf("terraform show failed: %w", err)
+	}
+	// Plans can contain sensitive values
+	if err := os.WriteFile(plan.JSONFile(planFile), planJSON, 0600); err != nil {
+		return nil, fmt.Errorf("failed to write plan JSON: %w", err)
+	}
+	if err := plan.WriteMeta(planFile, plan.Meta{
+		Environment: environment,
+		Manifest:    configFile,
+		Hash:        hash,
+		CreatedAt:   time.Now().UTC(),
+	}); err != nil {
+		return nil, err
+	}
+
+	summary, err := plan.Summarize(planJSON, env.BlueprintNames())
+	if err != nil {
+		return nil, err
+	}
+
+	return &savedPlan{File: planFile, Changes: changes, Summary: summary}, nil
+}
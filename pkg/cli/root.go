@@ -15,12 +15,17 @@
 package cli
 
 import (
+	"fmt"
+	"os"
+
+	"github.com/OplexTech/soloops-cli/pkg/config"
 	"github.com/spf13/cobra"
 )
 
 var (
 	configFile string
 	envName    string
+	varFlags   []string
 	version    string
 	gitCommit  string
 	buildDate  string
@@ -51,13 +56,84 @@ func Execute() error {
 func init() {
 	rootCmd.PersistentFlags().StringVarP(&configFile, "file", "f", "soloops.yaml", "Path to soloops.yaml manifest")
 	rootCmd.PersistentFlags().StringVarP(&envName, "env", "e", "", "Environment to target (defaults to first in manifest)")
+	rootCmd.PersistentFlags().StringArrayVar(&varFlags, "var", nil, "Set a manifest variable (key=value, repeatable)")
 
 	// Add subcommands
 	rootCmd.AddCommand(initCmd)
 	rootCmd.AddCommand(validateCmd)
+	rootCmd.AddCommand(migrateCmd)
+	rootCmd.AddCommand(importCmd)
 	rootCmd.AddCommand(generateCmd)
 	rootCmd.AddCommand(previewCmd)
 	rootCmd.AddCommand(applyCmd)
+	rootCmd.AddCommand(upCmd)
+	rootCmd.AddCommand(deployCmd)
 	rootCmd.AddCommand(destroyCmd)
+	rootCmd.AddCommand(driftCmd)
+	rootCmd.AddCommand(outputsCmd)
+	rootCmd.AddCommand(graphCmd)
 	rootCmd.AddCommand(versionCmd)
 }
+
+// loadConfig reads the manifest, interpolating --var flags, the environment
+// and .soloops.env files
+func loadConfig() (*config.Config, error) {
+	vars, err := config.ParseVars(varFlags)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := config.LoadWithOptions(configFile, config.LoadOptions{
+		Vars:        vars,
+		Environment: envName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// targetEnvName returns the --env environment, defaulting to the first one
+// in the manifest
+func targetEnvName(cfg *config.Config) string {
+	if envName == "" && len(cfg.Environments) > 0 {
+		return cfg.Environments[0].Name
+	}
+	return envName
+}
+
+// terraformEnv returns the process environment for Terraform, with
+// secret://env/ references exported as TF_VAR_ values
+func terraformEnv() ([]string, error) {
+	environ := os.Environ()
+
+	if _, err := os.Stat(configFile); err != nil {
+		return environ, nil
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	env, err := cfg.GetEnvironment(targetEnvName(cfg))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, bp := range env.Blueprints {
+		for _, value := range bp.Env {
+			ref, err := config.ParseSecretRef(value)
+			if err != nil || ref.Provider != config.SecretProviderEnv {
+				continue
+			}
+			secret, ok := os.LookupEnv(ref.Path)
+			if !ok {
+				return nil, fmt.Errorf("secret %s is not set in the environment", ref)
+			}
+			environ = append(environ, fmt.Sprintf("TF_VAR_%s=%s", ref.TerraformVariable(), secret))
+		}
+	}
+
+	return environ, nil
+}
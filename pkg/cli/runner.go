@@ -0,0 +1,134 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/OplexTech/soloops-cli/pkg/tf"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// RunnerFactory creates the Terraform runner used by the lifecycle commands
+type RunnerFactory func(opts tf.Options) tf.Runner
+
+var runnerFactory RunnerFactory = func(opts tf.Options) tf.Runner {
+	return tf.New(opts)
+}
+
+// SetRunnerFactory replaces how Terraform runners are created, e.g. with
+// (*tf.Fake).Runner in tests. It returns the previous factory.
+func SetRunnerFactory(factory RunnerFactory) RunnerFactory {
+	previous := runnerFactory
+	runnerFactory = factory
+	return previous
+}
+
+// sharedModulesDir holds the modules of the environments 'generate
+// --all-envs' writes to infra/<env>
+var sharedModulesDir = filepath.Join("infra", "modules")
+
+// infraDir returns the directory Terraform runs in for the target
+// environment: infra/<env> when 'generate --all-envs' created it, otherwise
+// infra
+func infraDir() string {
+	name := envName
+	if name == "" {
+		if _, err := os.Stat(filepath.Join("infra", "main.tf")); err == nil {
+			return "infra"
+		}
+		if cfg, err := loadConfig(); err == nil {
+			name = targetEnvName(cfg)
+		}
+	}
+	if name != "" {
+		dir := filepath.Join("infra", name)
+		if _, err := os.Stat(filepath.Join(dir, "main.tf")); err == nil {
+			return dir
+		}
+	}
+	return "infra"
+}
+
+// terraformDirs returns the generated directories Terraform reads when it
+// runs in dir: dir itself and, for infra/<env>, the shared modules
+func terraformDirs(dir string) []string {
+	if dir == "infra" {
+		return []string{dir}
+	}
+	return []string{dir, sharedModulesDir}
+}
+
+// newRunner creates a runner for infraDir wired to the command's streams, with
+// secret://env/ references passed through TF_VAR_ values
+func newRunner(cmd *cobra.Command) (tf.Runner, error) {
+	return newRunnerWithOutput(cmd, cmd.OutOrStdout())
+}
+
+// newRunnerWithOutput is newRunner with Terraform's output sent to stdout
+func newRunnerWithOutput(cmd *cobra.Command, stdout io.Writer) (tf.Runner, error) {
+	env, err := terraformEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	return runnerFactory(tf.Options{
+		Dir:    infraDir(),
+		Env:    env,
+		Stdin:  cmd.InOrStdin(),
+		Stdout: stdout,
+		Stderr: cmd.ErrOrStderr(),
+	}), nil
+}
+
+// ExecuteArgs runs the CLI with the given arguments and streams, resetting
+// flags left over from a previous run. It lets tests and embedders drive the
+// commands without a process boundary.
+func ExecuteArgs(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	resetFlags(rootCmd)
+
+	rootCmd.SetArgs(args)
+	rootCmd.SetIn(stdin)
+	rootCmd.SetOut(stdout)
+	rootCmd.SetErr(stderr)
+	defer func() {
+		rootCmd.SetArgs(nil)
+		rootCmd.SetIn(nil)
+		rootCmd.SetOut(nil)
+		rootCmd.SetErr(nil)
+	}()
+
+	return rootCmd.Execute()
+}
+
+func resetFlags(cmd *cobra.Command) {
+	reset := func(f *pflag.Flag) {
+		if slice, ok := f.Value.(pflag.SliceValue); ok {
+			_ = slice.Replace(nil)
+		} else {
+			_ = f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	}
+	cmd.Flags().VisitAll(reset)
+	cmd.PersistentFlags().VisitAll(reset)
+
+	for _, sub := range cmd.Commands() {
+		resetFlags(sub)
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/OplexTech/soloops-cli/pkg/generator"
+	"github.com/OplexTech/soloops-cli/pkg/plan"
+	"github.com/OplexTech/soloops-cli/pkg/tf"
+	"github.com/spf13/cobra"
+)
+
+var upYes bool
+
+var upCmd = &cobra.Command{
+	Use:   "up",
+	Short: "Validate, generate, preview and apply in one step",
+	Long: `Runs the whole pipeline for an environment:
+
+  1. validate  - check soloops.yaml
+  2. generate  - regenerate infra/ if the manifest changed since the last run
+  3. preview   - plan and summarize the changes (saved to infra/soloops.tfplan)
+  4. apply     - apply exactly the previewed plan, after confirmation
+
+The pipeline stops at the first failing stage. Nothing is applied when the
+plan has no changes.
+
+Flags:
+  --yes: Apply without asking for confirmation, e.g. in CI`,
+	RunE: runUp,
+}
+
+func init() {
+	upCmd.Flags().BoolVarP(&upYes, "yes", "y", false, "Apply without asking for confirmation")
+}
+
+func runUp(cmd *cobra.Command, args []string) error {
+	out := cmd.OutOrStdout()
+
+	stage := func(n int, name string) {
+		fmt.Fprintf(out, "\n▶ [%d/4] %s\n", n, name)
+	}
+	failed := func(name string, err error) error {
+		fmt.Fprintf(out, "✗ %s failed\n", name)
+		return fmt.Errorf("up stopped at %s: %w", name, err)
+	}
+
+	stage(1, "Validate")
+	cfg, err := loadConfig()
+	if err != nil {
+		return failed("validate", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return failed("validate", err)
+	}
+	env, err := cfg.GetEnvironment(targetEnvName(cfg))
+	if err != nil {
+		return failed("validate", err)
+	}
+	fmt.Fprintf(out, "✓ %s is valid (environment %s)\n", configFile, env.Name)
+
+	stage(2, "Generate")
+	dir := infraDir()
+	gens := []*generator.Generator{newGenerator(cfg, env, dir)}
+	if dir != "infra" {
+		gens = append(gens, newModulesGenerator(cfg))
+	}
+	upToDate, err := infraUpToDate(gens...)
+	if err != nil {
+		return failed("generate", err)
+	}
+	if upToDate {
+		fmt.Fprintf(out, "✓ %s/ is up to date with the manifest and overrides (skipped)\n", dir)
+	} else {
+		for _, gen := range gens {
+			if err := generateInfra(out, gen); err != nil {
+				return failed("generate", err)
+			}
+		}
+		fmt.Fprintf(out, "✓ Generated Terraform files in %s/ (%d blueprints)\n", dir, len(env.Blueprints))
+	}
+
+	stage(3, "Preview")
+	runner, err := newRunner(cmd)
+	if err != nil {
+		return failed("preview", err)
+	}
+	saved, err := savePlan(cmd, runner, cfg, env.Name, plan.DefaultFile, out)
+	if err != nil {
+		return failed("preview", err)
+	}
+	summary, err := saved.Summary.Render(plan.FormatText)
+	if err != nil {
+		return failed("preview", err)
+	}
+	fmt.Fprintf(out, "\n%s", summary)
+
+	if !saved.Changes {
+		fmt.Fprintf(out, "\n✓ No changes. %s is up to date.\n", env.Name)
+		return nil
+	}
+
+	stage(4, "Apply")
+	if !upYes {
+		fmt.Fprintf(out, "Apply these changes to %s? (yes/no): ", env.Name)
+
+		reader := bufio.NewReader(cmd.InOrStdin())
+		response, err := reader.ReadString('\n')
+		if err != nil {
+			return failed("apply", fmt.Errorf("failed to read input: %w", err))
+		}
+
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response != "yes" && response != "y" {
+			fmt.Fprintf(out, "Aborted. The plan is saved; run 'soloops apply --plan %s' to apply it later.\n", plan.DefaultFile)
+			return nil
+		}
+	}
+
+	if err := runner.Apply(cmd.Context(), tf.ApplyOptions{AutoApprove: true, PlanFile: saved.File}); err != nil {
+		return failed("apply", fmt.Errorf("terraform apply failed: %w", err))
+	}
+
+	fmt.Fprintf(out, "\n✓ %s is up to date\n", env.Name)
+	return nil
+}
@@ -17,7 +17,7 @@ package cli
 import (
 	"fmt"
 
-	"github.com/OplexTech/soloops-cli/pkg/config"
+	"github.com/OplexTech/soloops-cli/pkg/generator"
 	"github.com/spf13/cobra"
 )
 
@@ -31,6 +31,8 @@ Checks for:
   - Required fields (project, cloud, environments)
   - Budget constraints
   - Blueprint configurations
+  - Blueprint names, and conflicts between the physical names derived from them
+  - Dependency cycles between blueprints
   - Policy settings
 
 Returns detailed error messages with suggestions for fixing issues.`,
@@ -38,14 +40,20 @@ Returns detailed error messages with suggestions for fixing issues.`,
 }
 
 func runValidate(cmd *cobra.Command, args []string) error {
-	cfg, err := config.Load(configFile)
+	cfg, err := loadConfig()
 	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
+		return err
 	}
 
 	if err := cfg.Validate(); err != nil {
 		return fmt.Errorf("validation failed: %w", err)
 	}
+	for i := range cfg.Environments {
+		env := &cfg.Environments[i]
+		if err := generator.New(cfg, env).Validate(); err != nil {
+			return fmt.Errorf("validation failed: environment %s: %w", env.Name, err)
+		}
+	}
 
 	fmt.Printf("✓ Configuration is valid (%s)\n", configFile)
 	fmt.Printf("  Project: %s\n", cfg.Project)
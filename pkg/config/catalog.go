@@ -0,0 +1,72 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// SupportedClouds lists the cloud providers accepted in the manifest
+var SupportedClouds = []string{"aws", "gcp", "azure"}
+
+// SupportedRuntimes lists the web_api runtimes SoloOps can deploy
+var SupportedRuntimes = []string{"node18", "node20", "python3.11", "python3.12"}
+
+// Lambda instruction set architectures
+const (
+	ArchX86 = "x86_64"
+	ArchARM = "arm64"
+)
+
+// LambdaArchitectures lists the architectures a function can run on, the
+// default first. Every supported runtime runs on both.
+var LambdaArchitectures = []string{ArchX86, ArchARM}
+
+// lambdaRuntimes maps manifest runtimes to their AWS Lambda runtime
+// identifier. Lambda's memory, timeout and architecture limits are the same
+// for all of them, so they are checked against the global limits in
+// lambda.go.
+var lambdaRuntimes = map[string]string{
+	"node18":     "nodejs18.x",
+	"node20":     "nodejs20.x",
+	"python3.11": "python3.11",
+	"python3.12": "python3.12",
+}
+
+// LambdaRuntime returns the AWS Lambda runtime identifier for a manifest
+// runtime, or "" if it is not supported
+func LambdaRuntime(runtime string) string {
+	return lambdaRuntimes[runtime]
+}
+
+// RuntimeFromLambda returns the manifest runtime for an AWS Lambda runtime
+// identifier, or "" if it is not supported
+func RuntimeFromLambda(lambda string) string {
+	for runtime, id := range lambdaRuntimes {
+		if id == lambda {
+			return runtime
+		}
+	}
+	return ""
+}
+
+// SupportedDBTypes lists the database engines accepted for database blueprints
+var SupportedDBTypes = []string{"postgres", "mysql", "aurora_serverless_v2", "dynamodb"}
+
+// Contains reports whether value is one of options
+func Contains(options []string, value string) bool {
+	for _, option := range options {
+		if option == value {
+			return true
+		}
+	}
+	return false
+}
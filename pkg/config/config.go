@@ -17,16 +17,31 @@ package config
 import (
 	"fmt"
 	"os"
+	"regexp"
+	"sort"
 
+	"github.com/OplexTech/soloops-cli/pkg/naming"
 	"gopkg.in/yaml.v3"
 )
 
+var envVarPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Blueprint kinds
+const (
+	KindWebAPI     = "web_api"
+	KindStaticSite = "static_site"
+	KindDatabase   = "database"
+)
+
 // Config represents the top-level soloops.yaml structure
 type Config struct {
+	APIVersion   string        `yaml:"apiVersion,omitempty"`
 	Project      string        `yaml:"project"`
 	Cloud        string        `yaml:"cloud"`
 	Environments []Environment `yaml:"environments"`
 	Policies     *Policies     `yaml:"policies,omitempty"`
+	Modules      *Modules      `yaml:"modules,omitempty"`
+	Naming       *Naming       `yaml:"naming,omitempty"`
 }
 
 // Environment represents a deployment environment
@@ -40,11 +55,25 @@ type Environment struct {
 // Blueprint represents a generic infrastructure blueprint
 type Blueprint struct {
 	// Common fields
-	Type string `yaml:"type,omitempty"`
+	Type string `yaml:"type,omitempty"` // web_api, static_site or database
+
+	// Protect guards the blueprint's resources against destroy
+	Protect bool `yaml:"protect,omitempty"`
+
+	// Terraform patches attributes SoloOps does not model onto the
+	// blueprint's generated resources
+	Terraform Patches `yaml:"terraform,omitempty"`
 
 	// Web API fields
-	Runtime string `yaml:"runtime,omitempty"`
-	Ingress string `yaml:"ingress,omitempty"`
+	Runtime string            `yaml:"runtime,omitempty"`
+	Ingress string            `yaml:"ingress,omitempty"`
+	Env     map[string]string `yaml:"env,omitempty"`
+	Release *Release          `yaml:"release,omitempty"`
+	Lambda  `yaml:",inline"`
+
+	// Uses lists the blueprints of the environment the function accesses;
+	// each gets a scoped IAM policy, environment variables and network access
+	Uses []string `yaml:"uses,omitempty"`
 
 	// Database fields
 	DBType string `yaml:"db_type,omitempty"`
@@ -52,6 +81,10 @@ type Blueprint struct {
 	// Static site fields
 	Domain string `yaml:"domain,omitempty"`
 
+	// APIOrigin names a web_api of the environment the site's CloudFront
+	// distribution serves under /api/*, so the site calls it on its own domain
+	APIOrigin string `yaml:"api_origin,omitempty"`
+
 	// Additional raw fields
 	Raw map[string]interface{} `yaml:",inline"`
 }
@@ -62,15 +95,59 @@ type Policies struct {
 	DenyPublicS3 bool `yaml:"deny_public_s3,omitempty"`
 }
 
+// Naming controls how physical resource names are derived
+type Naming struct {
+	// AccountSuffix appends the account ID to S3 bucket names so they are
+	// globally unique. Turning it on for existing buckets replaces them, so
+	// it is only set for new projects.
+	AccountSuffix bool `yaml:"account_suffix,omitempty"`
+}
+
+// AccountSuffix reports whether bucket names end in the account ID
+func (c *Config) AccountSuffix() bool {
+	return c.Naming != nil && c.Naming.AccountSuffix
+}
+
+// ReservedEnvironmentNames cannot name environments: 'generate --all-envs'
+// writes each environment to infra/<env>, next to the modules it shares in
+// infra/modules
+var ReservedEnvironmentNames = []string{"modules"}
+
 // Load reads and parses a soloops.yaml file
 func Load(path string) (*Config, error) {
+	return LoadWithOptions(path, LoadOptions{})
+}
+
+// LoadWithOptions reads a soloops.yaml file and interpolates ${VAR}
+// placeholders from explicit vars, the environment and .soloops.env files
+func LoadWithOptions(path string, opts LoadOptions) (*Config, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+
+	resolver, err := newVariableResolver(path, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load variables: %w", err)
+	}
+	resolver.interpolate(&doc)
+	if err := resolver.err(); err != nil {
+		return nil, err
+	}
+
+	// Older schema versions are upgraded in memory; 'soloops migrate'
+	// rewrites the file itself
+	if _, err := MigrateDocument(&doc); err != nil {
+		return nil, err
+	}
+
 	var cfg Config
-	if err := yaml.Unmarshal(data, &cfg); err != nil {
+	if err := doc.Decode(&cfg); err != nil {
 		return nil, fmt.Errorf("failed to parse YAML: %w", err)
 	}
 
@@ -95,10 +172,28 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("at least one environment is required")
 	}
 
+	if c.Modules != nil {
+		if err := c.Modules.validate(); err != nil {
+			return fmt.Errorf("modules: %w", err)
+		}
+	}
+
+	seen := map[string]bool{}
 	for i, env := range c.Environments {
 		if env.Name == "" {
 			return fmt.Errorf("environment[%d]: name is required", i)
 		}
+		// Names become directories under infra/ with 'generate --all-envs'
+		if err := naming.ValidateKey(env.Name); err != nil {
+			return fmt.Errorf("environment[%d]: %w", i, err)
+		}
+		if seen[env.Name] {
+			return fmt.Errorf("environment[%d]: duplicate environment %q", i, env.Name)
+		}
+		seen[env.Name] = true
+		if Contains(ReservedEnvironmentNames, env.Name) {
+			return fmt.Errorf("environment[%d]: %q is reserved; 'generate --all-envs' writes infra/%s/ for shared files", i, env.Name, env.Name)
+		}
 		if env.Region == "" {
 			return fmt.Errorf("environment[%d] (%s): region is required", i, env.Name)
 		}
@@ -108,11 +203,129 @@ func (c *Config) Validate() error {
 		if len(env.Blueprints) == 0 {
 			return fmt.Errorf("environment[%d] (%s): at least one blueprint is required", i, env.Name)
 		}
+		for name, bp := range env.Blueprints {
+			if err := naming.ValidateKey(name); err != nil {
+				return fmt.Errorf("environment[%d] (%s): blueprint %w", i, env.Name, err)
+			}
+			if bp.Type == "" && c.APIVersion == APIVersionV1 {
+				return fmt.Errorf("environment[%d] (%s): blueprint %s: type is required by %s (%s, %s or %s)",
+					i, env.Name, name, APIVersionV1, KindWebAPI, KindStaticSite, KindDatabase)
+			}
+			if bp.Type != "" && !IsBlueprintKind(bp.Type) {
+				return fmt.Errorf("environment[%d] (%s): blueprint %s: unknown type %q (supported: %s, %s, %s)",
+					i, env.Name, name, bp.Type, KindWebAPI, KindStaticSite, KindDatabase)
+			}
+			if err := bp.validateEnv(); err != nil {
+				return fmt.Errorf("environment[%d] (%s): blueprint %s: %w", i, env.Name, name, err)
+			}
+			if err := bp.validateLambda(); err != nil {
+				return fmt.Errorf("environment[%d] (%s): blueprint %s: %w", i, env.Name, name, err)
+			}
+			if err := env.validateUses(name, bp); err != nil {
+				return fmt.Errorf("environment[%d] (%s): blueprint %s: %w", i, env.Name, name, err)
+			}
+			if err := env.validateAPIOrigin(bp); err != nil {
+				return fmt.Errorf("environment[%d] (%s): blueprint %s: %w", i, env.Name, name, err)
+			}
+			if len(bp.Terraform) > 0 {
+				if c.Modules != nil {
+					return fmt.Errorf("environment[%d] (%s): blueprint %s: terraform patches need the modules generated under infra/modules, which modules.source replaces", i, env.Name, name)
+				}
+				if err := bp.Terraform.validate(); err != nil {
+					return fmt.Errorf("environment[%d] (%s): blueprint %s: terraform: %w", i, env.Name, name, err)
+				}
+			}
+			if bp.Release != nil {
+				if bp.Kind() != KindWebAPI {
+					return fmt.Errorf("environment[%d] (%s): blueprint %s: release is only supported for %s blueprints", i, env.Name, name, KindWebAPI)
+				}
+				if err := bp.Release.validate(); err != nil {
+					return fmt.Errorf("environment[%d] (%s): blueprint %s: release: %w", i, env.Name, name, err)
+				}
+			}
+		}
+		if _, err := env.Order(); err != nil {
+			return fmt.Errorf("environment[%d] (%s): %w", i, env.Name, err)
+		}
 	}
 
 	return nil
 }
 
+// Kind returns the blueprint type, inferring it from the fields present
+// when it is not set explicitly
+func (b Blueprint) Kind() string {
+	if b.Type != "" {
+		return b.Type
+	}
+	return inferKind(b.Runtime != "" || b.Ingress != "", b.Domain != "", b.DBType != "")
+}
+
+// IsBlueprintKind reports whether kind is a supported blueprint type
+func IsBlueprintKind(kind string) bool {
+	switch kind {
+	case KindWebAPI, KindStaticSite, KindDatabase:
+		return true
+	}
+	return false
+}
+
+func inferKind(hasRuntime, hasDomain, hasDBType bool) string {
+	switch {
+	case hasRuntime:
+		return KindWebAPI
+	case hasDomain:
+		return KindStaticSite
+	case hasDBType:
+		return KindDatabase
+	}
+	return ""
+}
+
+// validateEnv checks environment variable names and secret references, and
+// keeps secrets out of fields that would be rendered as plaintext
+func (b Blueprint) validateEnv() error {
+	keys := make([]string, 0, len(b.Env))
+	for key := range b.Env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if !envVarPattern.MatchString(key) {
+			return fmt.Errorf("env %s: invalid variable name (use letters, digits and underscores)", key)
+		}
+		if value := b.Env[key]; IsSecretRef(value) {
+			if _, err := ParseSecretRef(value); err != nil {
+				return fmt.Errorf("env %s: %w", key, err)
+			}
+		}
+	}
+
+	for field, value := range map[string]string{
+		"runtime": b.Runtime,
+		"ingress": b.Ingress,
+		"db_type": b.DBType,
+		"domain":  b.Domain,
+	} {
+		if IsSecretRef(value) {
+			return fmt.Errorf("%s: secret references are only supported in env values", field)
+		}
+	}
+
+	return nil
+}
+
+// BlueprintNames returns the environment's blueprint names in sorted order
+func (e *Environment) BlueprintNames() []string {
+	names := make([]string, 0, len(e.Blueprints))
+	for name := range e.Blueprints {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // GetEnvironment returns an environment by name
 func (c *Config) GetEnvironment(name string) (*Environment, error) {
 	for _, env := range c.Environments {
@@ -0,0 +1,103 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Dependencies returns the blueprints of the environment that the blueprint
+// name references through uses and api_origin, in manifest order
+func (e *Environment) Dependencies(name string) []string {
+	bp := e.Blueprints[name]
+	refs := bp.Uses
+	if bp.APIOrigin != "" {
+		refs = append(refs[:len(refs):len(refs)], bp.APIOrigin)
+	}
+
+	var deps []string
+	for _, ref := range refs {
+		if _, ok := e.Blueprints[ref]; ok {
+			deps = append(deps, ref)
+		}
+	}
+	return deps
+}
+
+// Order returns the environment's blueprint names with every blueprint
+// after the ones it depends on, and otherwise in sorted order. It fails
+// when the dependencies form a cycle.
+func (e *Environment) Order() ([]string, error) {
+	placed := map[string]bool{}
+	order := make([]string, 0, len(e.Blueprints))
+	names := e.BlueprintNames()
+
+	for len(order) < len(names) {
+		next := ""
+		for _, name := range names {
+			if !placed[name] && e.ready(name, placed) {
+				next = name
+				break
+			}
+		}
+		if next == "" {
+			return nil, fmt.Errorf("dependency cycle: %s", strings.Join(e.cycle(placed), " -> "))
+		}
+		placed[next] = true
+		order = append(order, next)
+	}
+	return order, nil
+}
+
+func (e *Environment) ready(name string, placed map[string]bool) bool {
+	for _, dep := range e.Dependencies(name) {
+		if !placed[dep] {
+			return false
+		}
+	}
+	return true
+}
+
+// cycle follows unplaced dependencies from the first unplaced blueprint
+// until one repeats, and returns the path from that blueprint back to itself
+func (e *Environment) cycle(placed map[string]bool) []string {
+	var path []string
+	index := map[string]int{}
+	for _, name := range e.BlueprintNames() {
+		if !placed[name] {
+			path = append(path, name)
+			break
+		}
+	}
+
+	for {
+		current := path[len(path)-1]
+		if i, ok := index[current]; ok {
+			return path[i:]
+		}
+		index[current] = len(path) - 1
+
+		deps := e.Dependencies(current)
+		sort.Strings(deps)
+		for _, dep := range deps {
+			if !placed[dep] {
+				path = append(path, dep)
+				break
+			}
+		}
+	}
+}
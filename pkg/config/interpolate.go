@@ -0,0 +1,216 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EnvFileName is the dotenv file read from the manifest directory
+const EnvFileName = ".soloops.env"
+
+// LoadOptions controls variable interpolation while loading a manifest
+type LoadOptions struct {
+	// Vars are explicit values (e.g. from --var flags). They take precedence
+	// over everything else.
+	Vars map[string]string
+
+	// Environment selects an additional .soloops.<env>.env file
+	Environment string
+
+	// LookupEnv resolves process environment variables (defaults to os.LookupEnv)
+	LookupEnv func(string) (string, bool)
+}
+
+// placeholderPattern matches ${NAME} and ${NAME:-default}. Anything else inside
+// ${...} (such as Terraform expressions) is left untouched.
+var placeholderPattern = regexp.MustCompile(`\$?\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// variableResolver looks up interpolation values in precedence order:
+// explicit vars, process environment, .soloops.<env>.env, .soloops.env
+type variableResolver struct {
+	vars      map[string]string
+	lookupEnv func(string) (string, bool)
+	files     map[string]string
+	missing   map[string]bool
+}
+
+func newVariableResolver(path string, opts LoadOptions) (*variableResolver, error) {
+	r := &variableResolver{
+		vars:      opts.Vars,
+		lookupEnv: opts.LookupEnv,
+		files:     map[string]string{},
+		missing:   map[string]bool{},
+	}
+	if r.lookupEnv == nil {
+		r.lookupEnv = os.LookupEnv
+	}
+
+	dir := filepath.Dir(path)
+	envFiles := []string{filepath.Join(dir, EnvFileName)}
+	if opts.Environment != "" {
+		envFiles = append(envFiles, filepath.Join(dir, fmt.Sprintf(".soloops.%s.env", opts.Environment)))
+	}
+
+	// Later files override earlier ones
+	for _, file := range envFiles {
+		values, err := ReadEnvFile(file)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range values {
+			r.files[k] = v
+		}
+	}
+
+	return r, nil
+}
+
+func (r *variableResolver) lookup(name string) (string, bool) {
+	if v, ok := r.vars[name]; ok {
+		return v, true
+	}
+	if v, ok := r.lookupEnv(name); ok {
+		return v, true
+	}
+	v, ok := r.files[name]
+	return v, ok
+}
+
+// expand replaces placeholders in a single scalar value
+func (r *variableResolver) expand(value string) string {
+	return placeholderPattern.ReplaceAllStringFunc(value, func(match string) string {
+		// $${NAME} escapes interpolation and yields a literal ${NAME}
+		if strings.HasPrefix(match, "$$") {
+			return match[1:]
+		}
+
+		groups := placeholderPattern.FindStringSubmatch(match)
+		name, hasDefault, def := groups[1], groups[2] != "", groups[3]
+
+		if v, ok := r.lookup(name); ok && (v != "" || !hasDefault) {
+			return v
+		}
+		if hasDefault {
+			return def
+		}
+
+		r.missing[name] = true
+		return match
+	})
+}
+
+// interpolate walks a YAML document and expands placeholders in scalar values
+func (r *variableResolver) interpolate(node *yaml.Node) {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range node.Content {
+			r.interpolate(child)
+		}
+	case yaml.MappingNode:
+		// Only values are interpolated; keys are part of the schema
+		for i := 1; i < len(node.Content); i += 2 {
+			r.interpolate(node.Content[i])
+		}
+	case yaml.ScalarNode:
+		expanded := r.expand(node.Value)
+		if expanded == node.Value {
+			return
+		}
+		node.Value = expanded
+		// Let plain scalars re-resolve so "budget_usd: ${BUDGET}" decodes as a number
+		if node.Style == 0 {
+			node.Tag = ""
+		}
+	}
+}
+
+func (r *variableResolver) err() error {
+	if len(r.missing) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(r.missing))
+	for name := range r.missing {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return fmt.Errorf("undefined variables: %s (set them with --var, the environment or %s)",
+		strings.Join(names, ", "), EnvFileName)
+}
+
+// ReadEnvFile parses a dotenv-style file of KEY=VALUE lines. Blank lines,
+// comments and an optional "export " prefix are allowed.
+func ReadEnvFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	values := map[string]string{}
+	scanner := bufio.NewScanner(f)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("%s:%d: expected KEY=VALUE", path, lineNo)
+		}
+
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		values[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	return values, nil
+}
+
+// ParseVars converts --var style "key=value" pairs into a map
+func ParseVars(pairs []string) (map[string]string, error) {
+	vars := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid variable %q (expected key=value)", pair)
+		}
+		vars[strings.TrimSpace(key)] = value
+	}
+	return vars, nil
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Lambda function limits and the defaults used when the manifest does not
+// set a value. The limits are Lambda's own and apply to every runtime.
+const (
+	DefaultLambdaMemory  = 256
+	DefaultLambdaTimeout = 10
+
+	MinLambdaMemory = 128
+	MaxLambdaMemory = 10240
+	MaxLambdaLayers = 5
+
+	// MaxLambdaTimeout is the API Gateway integration timeout; a function
+	// running longer cannot answer the request
+	MaxLambdaTimeout = 29
+)
+
+var (
+	layerARN      = regexp.MustCompile(`^arn:aws[a-z-]*:lambda:[a-z0-9-]+:\d{12}:layer:[A-Za-z0-9_-]+:\d+$`)
+	deadLetterARN = regexp.MustCompile(`^arn:aws[a-z-]*:(sqs|sns):[a-z0-9-]+:\d{12}:[A-Za-z0-9_.-]+$`)
+)
+
+// Lambda configures the function of a web_api blueprint
+type Lambda struct {
+	Memory              int      `yaml:"memory,omitempty"`               // MB, defaults to 256
+	Timeout             int      `yaml:"timeout,omitempty"`              // seconds, defaults to 10
+	Architecture        string   `yaml:"architecture,omitempty"`         // x86_64 or arm64
+	Layers              []string `yaml:"layers,omitempty"`               // layer version ARNs
+	ReservedConcurrency *int     `yaml:"reserved_concurrency,omitempty"` // unreserved when unset
+	Tracing             bool     `yaml:"tracing,omitempty"`              // X-Ray active tracing
+	DeadLetter          string   `yaml:"dead_letter,omitempty"`          // SQS queue or SNS topic ARN for failed async invocations
+}
+
+// LambdaSettings returns the function settings with defaults applied
+func (b Blueprint) LambdaSettings() Lambda {
+	l := b.Lambda
+	if l.Memory == 0 {
+		l.Memory = DefaultLambdaMemory
+	}
+	if l.Timeout == 0 {
+		l.Timeout = DefaultLambdaTimeout
+	}
+	if l.Architecture == "" {
+		l.Architecture = LambdaArchitectures[0]
+	}
+	return l
+}
+
+// LambdaRuntimeName returns the manifest runtime of a web_api, defaulting
+// to the first supported runtime
+func (b Blueprint) LambdaRuntimeName() string {
+	if b.Runtime == "" {
+		return SupportedRuntimes[0]
+	}
+	return b.Runtime
+}
+
+func (l Lambda) set() bool {
+	return l.Memory != 0 || l.Timeout != 0 || l.Architecture != "" || len(l.Layers) > 0 ||
+		l.ReservedConcurrency != nil || l.Tracing || l.DeadLetter != ""
+}
+
+// validateLambda checks the runtime and the function settings against
+// Lambda's limits
+func (b Blueprint) validateLambda() error {
+	if b.Kind() != KindWebAPI {
+		if b.Lambda.set() {
+			return fmt.Errorf("function settings are only supported for %s blueprints", KindWebAPI)
+		}
+		return nil
+	}
+
+	if _, ok := lambdaRuntimes[b.LambdaRuntimeName()]; !ok {
+		return fmt.Errorf("unsupported runtime %q (supported: %s)", b.Runtime, strings.Join(SupportedRuntimes, ", "))
+	}
+
+	l := b.Lambda
+	if l.Memory != 0 && (l.Memory < MinLambdaMemory || l.Memory > MaxLambdaMemory) {
+		return fmt.Errorf("memory %d must be between %d and %d MB", l.Memory, MinLambdaMemory, MaxLambdaMemory)
+	}
+	if l.Timeout < 0 || l.Timeout > MaxLambdaTimeout {
+		return fmt.Errorf("timeout %d must be between 1 and %d seconds, the API Gateway integration limit", l.Timeout, MaxLambdaTimeout)
+	}
+	if l.Architecture != "" && !Contains(LambdaArchitectures, l.Architecture) {
+		return fmt.Errorf("unsupported architecture %q (supported: %s)", l.Architecture, strings.Join(LambdaArchitectures, ", "))
+	}
+	if len(l.Layers) > MaxLambdaLayers {
+		return fmt.Errorf("at most %d layers are supported, got %d", MaxLambdaLayers, len(l.Layers))
+	}
+	for _, layer := range l.Layers {
+		if !layerARN.MatchString(layer) {
+			return fmt.Errorf("layer %q is not a layer version ARN (arn:aws:lambda:<region>:<account>:layer:<name>:<version>)", layer)
+		}
+	}
+	if l.ReservedConcurrency != nil && *l.ReservedConcurrency < 0 {
+		return fmt.Errorf("reserved_concurrency must not be negative, got %d", *l.ReservedConcurrency)
+	}
+	if l.DeadLetter != "" && !deadLetterARN.MatchString(l.DeadLetter) {
+		return fmt.Errorf("dead_letter %q is not an SQS queue or SNS topic ARN", l.DeadLetter)
+	}
+	return nil
+}
@@ -0,0 +1,238 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Manifest schema versions
+const (
+	// APIVersionV1Alpha1 is the original, unversioned format. Manifests
+	// without an apiVersion key are treated as this version.
+	APIVersionV1Alpha1 = "soloops/v1alpha1"
+
+	// APIVersionV1 requires an explicit blueprint type
+	APIVersionV1 = "soloops/v1"
+
+	// CurrentAPIVersion is the version written by init and migrate
+	CurrentAPIVersion = APIVersionV1
+)
+
+// migration upgrades a manifest document from one version to the next
+type migration struct {
+	from  string
+	to    string
+	apply func(root *yaml.Node) error
+}
+
+// migrations are applied in order until the document reaches CurrentAPIVersion
+var migrations = []migration{
+	{from: APIVersionV1Alpha1, to: APIVersionV1, apply: migrateV1Alpha1ToV1},
+}
+
+// MigrationResult describes the outcome of MigrateDocument
+type MigrationResult struct {
+	From    string
+	To      string
+	Changed bool
+}
+
+// DetectVersion returns the apiVersion declared by a manifest document
+func DetectVersion(doc *yaml.Node) string {
+	root := documentRoot(doc)
+	if root == nil {
+		return APIVersionV1Alpha1
+	}
+	if value := mappingValue(root, "apiVersion"); value != nil && value.Value != "" {
+		return value.Value
+	}
+	return APIVersionV1Alpha1
+}
+
+// MigrateDocument upgrades a parsed manifest to CurrentAPIVersion in place.
+// Working on yaml.Node keeps comments and key order intact.
+func MigrateDocument(doc *yaml.Node) (MigrationResult, error) {
+	version := DetectVersion(doc)
+	result := MigrationResult{From: version, To: version}
+
+	root := documentRoot(doc)
+	if root == nil {
+		return result, fmt.Errorf("manifest must be a YAML mapping")
+	}
+
+	for version != CurrentAPIVersion {
+		step := findMigration(version)
+		if step == nil {
+			return result, fmt.Errorf("unsupported apiVersion: %s (latest: %s)", version, CurrentAPIVersion)
+		}
+		if err := step.apply(root); err != nil {
+			return result, fmt.Errorf("migrating %s to %s: %w", step.from, step.to, err)
+		}
+		version = step.to
+		result.Changed = true
+	}
+
+	if result.Changed {
+		setAPIVersion(root, version)
+	}
+	result.To = version
+
+	return result, nil
+}
+
+// MigrateBytes upgrades manifest source and re-encodes it
+func MigrateBytes(data []byte) ([]byte, MigrationResult, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, MigrationResult{}, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+
+	result, err := MigrateDocument(&doc)
+	if err != nil {
+		return nil, result, err
+	}
+	if !result.Changed {
+		return data, result, nil
+	}
+
+	var out bytes.Buffer
+	enc := yaml.NewEncoder(&out)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, result, fmt.Errorf("failed to encode YAML: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, result, fmt.Errorf("failed to encode YAML: %w", err)
+	}
+
+	return out.Bytes(), result, nil
+}
+
+func findMigration(from string) *migration {
+	for i := range migrations {
+		if migrations[i].from == from {
+			return &migrations[i]
+		}
+	}
+	return nil
+}
+
+// migrateV1Alpha1ToV1 makes every blueprint's type explicit. In v1alpha1 the
+// type was inferred from the fields present, and "type" on a database
+// blueprint named the engine (e.g. aurora_serverless_v2); that moves to
+// db_type.
+func migrateV1Alpha1ToV1(root *yaml.Node) error {
+	envs := mappingValue(root, "environments")
+	if envs == nil || envs.Kind != yaml.SequenceNode {
+		return nil
+	}
+
+	for _, env := range envs.Content {
+		if env.Kind != yaml.MappingNode {
+			continue
+		}
+		blueprints := mappingValue(env, "blueprints")
+		if blueprints == nil || blueprints.Kind != yaml.MappingNode {
+			continue
+		}
+
+		for i := 1; i < len(blueprints.Content); i += 2 {
+			bp := blueprints.Content[i]
+			if bp.Kind != yaml.MappingNode {
+				continue
+			}
+
+			typeNode := mappingValue(bp, "type")
+			if typeNode != nil && IsBlueprintKind(typeNode.Value) {
+				continue
+			}
+
+			if typeNode != nil {
+				// Legacy engine name: type -> db_type
+				if mappingValue(bp, "db_type") != nil {
+					return fmt.Errorf("blueprint %s: both type and db_type are set", blueprints.Content[i-1].Value)
+				}
+				renameKey(bp, "type", "db_type")
+			}
+
+			kind := inferKind(mappingValue(bp, "runtime") != nil || mappingValue(bp, "ingress") != nil,
+				mappingValue(bp, "domain") != nil,
+				mappingValue(bp, "db_type") != nil)
+			if kind == "" {
+				continue
+			}
+			prependKey(bp, "type", kind)
+		}
+	}
+
+	return nil
+}
+
+func setAPIVersion(root *yaml.Node, version string) {
+	if value := mappingValue(root, "apiVersion"); value != nil {
+		value.Value = version
+		return
+	}
+	prependKey(root, "apiVersion", version)
+
+	// A head comment on the old first key usually describes the whole
+	// document; keep it at the top
+	if len(root.Content) < 4 {
+		return
+	}
+	if first := root.Content[2]; first.HeadComment != "" {
+		root.Content[0].HeadComment = first.HeadComment
+		first.HeadComment = ""
+	}
+}
+
+func documentRoot(doc *yaml.Node) *yaml.Node {
+	if doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 {
+		doc = doc.Content[0]
+	}
+	if doc.Kind != yaml.MappingNode {
+		return nil
+	}
+	return doc
+}
+
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func renameKey(mapping *yaml.Node, from, to string) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == from {
+			mapping.Content[i].Value = to
+			return
+		}
+	}
+}
+
+// prependKey inserts a scalar key/value at the start of a mapping
+func prependKey(mapping *yaml.Node, key, value string) {
+	k := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}
+	v := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+	mapping.Content = append([]*yaml.Node{k, v}, mapping.Content...)
+}
@@ -0,0 +1,60 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Modules pins the Terraform modules generated code instantiates to a shared
+// registry or git source instead of the local copies under infra/modules
+type Modules struct {
+	// Source is a registry address (acme/soloops/aws), a git URL
+	// (git::https://example.com/modules.git) or a local path; each module is
+	// read from its modules/<name> subdirectory
+	Source string `yaml:"source"`
+
+	// Version is the registry version constraint or git ref
+	Version string `yaml:"version,omitempty"`
+}
+
+// IsGit reports whether the source is a git repository
+func (m *Modules) IsGit() bool {
+	for _, prefix := range []string{"git::", "git@", "github.com/", "bitbucket.org/"} {
+		if strings.HasPrefix(m.Source, prefix) {
+			return true
+		}
+	}
+	return strings.Contains(m.Source, ".git")
+}
+
+// IsLocal reports whether the source is a local path
+func (m *Modules) IsLocal() bool {
+	return strings.HasPrefix(m.Source, "./") || strings.HasPrefix(m.Source, "../") || strings.HasPrefix(m.Source, "/")
+}
+
+func (m *Modules) validate() error {
+	if m.Source == "" {
+		return fmt.Errorf("source is required")
+	}
+	if strings.ContainsAny(m.Source, "?") {
+		return fmt.Errorf("source %q must not contain a query string; set the git ref with version", m.Source)
+	}
+	if m.Version != "" && m.IsLocal() {
+		return fmt.Errorf("version is not supported for local source %q", m.Source)
+	}
+	return nil
+}
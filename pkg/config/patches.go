@@ -0,0 +1,61 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/OplexTech/soloops-cli/pkg/hcl"
+)
+
+// Patches sets raw attributes on a blueprint's generated resources, keyed by
+// resource address within its module (e.g. aws_lambda_function.this) and
+// then by attribute name
+type Patches map[string]map[string]interface{}
+
+// Resources returns the patched resource addresses in order
+func (p Patches) Resources() []string {
+	addresses := make([]string, 0, len(p))
+	for address := range p {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+	return addresses
+}
+
+// validate checks the shape of the patches; whether each resource exists is
+// checked by the generator, which knows the module's resources
+func (p Patches) validate() error {
+	for _, address := range p.Resources() {
+		parts := strings.Split(address, ".")
+		if len(parts) != 2 || !hcl.IsIdentifier(parts[0]) || !hcl.IsIdentifier(parts[1]) {
+			return fmt.Errorf("%q is not a resource address such as aws_lambda_function.this", address)
+		}
+		if len(p[address]) == 0 {
+			return fmt.Errorf("%s: no attributes to set", address)
+		}
+		for name, value := range p[address] {
+			if !hcl.IsIdentifier(name) {
+				return fmt.Errorf("%s: %q is not an attribute name", address, name)
+			}
+			if _, err := hcl.Value(value); err != nil {
+				return fmt.Errorf("%s.%s: %w", address, name, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// Release strategies for web_api blueprints
+const (
+	ReleaseBlueGreen = "blue_green"
+	ReleaseCanary    = "canary"
+)
+
+// ReleaseStrategies lists the supported release strategies
+var ReleaseStrategies = []string{ReleaseBlueGreen, ReleaseCanary}
+
+// DefaultReleaseInterval is how long each traffic step is held when the
+// manifest does not set an interval
+const DefaultReleaseInterval = 5 * time.Minute
+
+// Release configures how 'soloops deploy' shifts traffic to a new version of
+// a web_api function
+type Release struct {
+	Strategy string `yaml:"strategy"`
+	Steps    []int  `yaml:"steps,omitempty"`    // percentages of traffic, ending at 100
+	Interval string `yaml:"interval,omitempty"` // how long each step is held, e.g. 5m
+}
+
+// TrafficSteps returns the percentages of traffic sent to the new version in
+// order. Blue/green switches at once; canary defaults to 10% then 100%.
+func (r *Release) TrafficSteps() []int {
+	if r.Strategy == ReleaseBlueGreen {
+		return []int{100}
+	}
+	if len(r.Steps) == 0 {
+		return []int{10, 100}
+	}
+	return r.Steps
+}
+
+// IntervalDuration returns how long each step is held while alarms are
+// watched
+func (r *Release) IntervalDuration() time.Duration {
+	if r.Interval == "" {
+		return DefaultReleaseInterval
+	}
+	d, err := time.ParseDuration(r.Interval)
+	if err != nil {
+		return DefaultReleaseInterval // rejected by validate
+	}
+	return d
+}
+
+func (r *Release) validate() error {
+	if !Contains(ReleaseStrategies, r.Strategy) {
+		return fmt.Errorf("unknown strategy %q (supported: %s, %s)", r.Strategy, ReleaseBlueGreen, ReleaseCanary)
+	}
+
+	if r.Interval != "" {
+		d, err := time.ParseDuration(r.Interval)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("interval %q must be a positive duration such as 5m", r.Interval)
+		}
+	}
+
+	if len(r.Steps) > 0 && r.Strategy == ReleaseBlueGreen {
+		return fmt.Errorf("steps are only supported for the %s strategy", ReleaseCanary)
+	}
+	previous := 0
+	for _, step := range r.Steps {
+		if step <= previous || step > 100 {
+			return fmt.Errorf("steps must increase between 1 and 100, got %v", r.Steps)
+		}
+		previous = step
+	}
+	if len(r.Steps) > 0 && previous != 100 {
+		return fmt.Errorf("steps must end at 100, got %v", r.Steps)
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SecretScheme prefixes values that reference a secret instead of holding it
+const SecretScheme = "secret://"
+
+// Secret providers
+const (
+	SecretProviderSSM            = "aws-ssm"
+	SecretProviderSecretsManager = "aws-secretsmanager"
+	SecretProviderEnv            = "env"
+)
+
+// SecretRef is a parsed secret://<provider>/<path> reference
+type SecretRef struct {
+	Provider string
+	Path     string
+}
+
+// String returns the reference in its manifest form
+func (s SecretRef) String() string {
+	return SecretScheme + s.Provider + "/" + strings.TrimPrefix(s.Path, "/")
+}
+
+// Identifier returns a Terraform-safe name derived from the secret path
+func (s SecretRef) Identifier() string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.Trim(s.Path, "/")) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+	id := b.String()
+	if id == "" || (id[0] >= '0' && id[0] <= '9') {
+		id = "s_" + id
+	}
+	return id
+}
+
+// TerraformVariable returns the sensitive input variable that carries an env
+// secret into Terraform
+func (s SecretRef) TerraformVariable() string {
+	return "secret_" + s.Identifier()
+}
+
+// IsSecretRef reports whether a value uses the secret:// scheme
+func IsSecretRef(value string) bool {
+	return strings.HasPrefix(value, SecretScheme)
+}
+
+// ParseSecretRef parses a secret://<provider>/<path> reference
+func ParseSecretRef(value string) (SecretRef, error) {
+	if !IsSecretRef(value) {
+		return SecretRef{}, fmt.Errorf("not a secret reference: %s", value)
+	}
+
+	provider, path, _ := strings.Cut(strings.TrimPrefix(value, SecretScheme), "/")
+	if path == "" {
+		return SecretRef{}, fmt.Errorf("invalid secret reference %q (expected secret://<provider>/<path>)", value)
+	}
+
+	switch provider {
+	case SecretProviderSSM, SecretProviderSecretsManager:
+		// SSM parameter names are usually absolute; keep the leading slash
+		if provider == SecretProviderSSM && !strings.HasPrefix(path, "/") {
+			path = "/" + path
+		}
+	case SecretProviderEnv:
+		if strings.Contains(path, "/") {
+			return SecretRef{}, fmt.Errorf("invalid secret reference %q (env secrets take a variable name)", value)
+		}
+	default:
+		return SecretRef{}, fmt.Errorf("unsupported secret provider %q in %s (supported: %s, %s, %s)",
+			provider, value, SecretProviderSSM, SecretProviderSecretsManager, SecretProviderEnv)
+	}
+
+	return SecretRef{Provider: provider, Path: path}, nil
+}
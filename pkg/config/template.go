@@ -16,7 +16,8 @@ package config
 
 // DefaultTemplate returns a starter soloops.yaml template
 func DefaultTemplate() string {
-	return `project: my-project
+	return `apiVersion: soloops/v1
+project: my-project
 cloud: aws
 environments:
   - name: prod
@@ -24,12 +25,16 @@ environments:
     budget_usd: 150
     blueprints:
       web_api:
+        type: web_api
         runtime: node18
         ingress: edge
       static_site:
+        type: static_site
         domain: example.com
 policies:
   require_https: true
   deny_public_s3: true
+naming:
+  account_suffix: true
 `
 }
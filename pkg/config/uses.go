@@ -0,0 +1,113 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Relational reports whether the blueprint is an RDS or Aurora database,
+// which functions reach over the network
+func (b Blueprint) Relational() bool {
+	if b.Kind() != KindDatabase {
+		return false
+	}
+	switch b.DBType {
+	case "postgres", "mysql", "aurora_serverless_v2":
+		return true
+	}
+	return false
+}
+
+// UsedEnv returns the environment variables a web_api that uses the
+// blueprint name receives, mapped to the outputs of the blueprint's module
+// holding their values
+func (b Blueprint) UsedEnv(name string) map[string]string {
+	prefix := strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+	switch {
+	case b.Kind() == KindWebAPI:
+		return map[string]string{prefix + "FUNCTION": "live_alias_arn"}
+	case b.Kind() == KindStaticSite:
+		return map[string]string{prefix + "BUCKET": "bucket_name"}
+	case b.Kind() == KindDatabase && b.DBType == "dynamodb":
+		return map[string]string{prefix + "TABLE": "table_name"}
+	case b.Relational():
+		return map[string]string{prefix + "ENDPOINT": "endpoint", prefix + "SECRET_ARN": "secret_arn"}
+	}
+	return nil
+}
+
+// validateUses checks that a web_api only uses other blueprints of the
+// environment, and that the variables it receives for them do not clash
+// with its own env
+func (e *Environment) validateUses(name string, bp Blueprint) error {
+	if len(bp.Uses) == 0 {
+		return nil
+	}
+	if bp.Kind() != KindWebAPI {
+		return fmt.Errorf("uses is only supported for %s blueprints", KindWebAPI)
+	}
+
+	seen := map[string]bool{}
+	for _, used := range bp.Uses {
+		target, ok := e.Blueprints[used]
+		switch {
+		case used == name:
+			return fmt.Errorf("uses: a blueprint cannot use itself")
+		case !ok:
+			return fmt.Errorf("uses: unknown blueprint %q", used)
+		case seen[used]:
+			return fmt.Errorf("uses: %s is listed twice", used)
+		}
+		seen[used] = true
+
+		env := target.UsedEnv(used)
+		if env == nil {
+			return fmt.Errorf("uses: %s (%s %s) cannot be used", used, target.Kind(), target.DBType)
+		}
+		keys := make([]string, 0, len(env))
+		for key := range env {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if _, ok := bp.Env[key]; ok {
+				return fmt.Errorf("env %s: set by uses for %s", key, used)
+			}
+		}
+	}
+	return nil
+}
+
+// validateAPIOrigin checks that a static_site's api_origin is a web_api of
+// the environment
+func (e *Environment) validateAPIOrigin(bp Blueprint) error {
+	if bp.APIOrigin == "" {
+		return nil
+	}
+	if bp.Kind() != KindStaticSite {
+		return fmt.Errorf("api_origin is only supported for %s blueprints", KindStaticSite)
+	}
+	target, ok := e.Blueprints[bp.APIOrigin]
+	switch {
+	case !ok:
+		return fmt.Errorf("api_origin: unknown blueprint %q", bp.APIOrigin)
+	case target.Kind() != KindWebAPI:
+		return fmt.Errorf("api_origin: %s is a %s, not a %s", bp.APIOrigin, target.Kind(), KindWebAPI)
+	}
+	return nil
+}
@@ -0,0 +1,249 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MaxZipSize is the largest package Lambda accepts as a direct upload
+const MaxZipSize = 50 << 20
+
+// zipEpoch is the modification time of every packaged file, so the same
+// sources always produce the same package and CodeSha256
+var zipEpoch = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// BuildOptions control how a function package is built
+type BuildOptions struct {
+	Dir          string    // source directory
+	Runtime      string    // manifest runtime, e.g. node18 or python3.12
+	Architecture string    // Lambda architecture, x86_64 (default) or arm64
+	Output       io.Writer // dependency installer output
+}
+
+// BuildPackage installs the source's dependencies into a staging directory
+// and zips it. Python requirements.txt entries are installed with pip as
+// wheels for the function's Lambda platform, whatever the build machine;
+// Node.js dependencies with npm unless node_modules is already present.
+func BuildPackage(opts BuildOptions) ([]byte, error) {
+	info, err := os.Stat(opts.Dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", opts.Dir)
+	}
+
+	stage, err := os.MkdirTemp("", "soloops-package-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(stage)
+
+	if err := copyTree(opts.Dir, stage); err != nil {
+		return nil, fmt.Errorf("failed to stage %s: %w", opts.Dir, err)
+	}
+
+	switch {
+	case strings.HasPrefix(opts.Runtime, "python"):
+		if hasRequirements(filepath.Join(stage, "requirements.txt")) {
+			if err := run(stage, opts.Output, "pip", PipInstallArgs(opts.Runtime, opts.Architecture)...); err != nil {
+				return nil, err
+			}
+		}
+	case strings.HasPrefix(opts.Runtime, "node"):
+		_, err := os.Stat(filepath.Join(stage, "node_modules"))
+		if os.IsNotExist(err) && hasNodeDependencies(filepath.Join(stage, "package.json")) {
+			if err := run(stage, opts.Output, "npm", "install", "--omit=dev", "--no-audit", "--no-fund"); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	data, err := Zip(stage)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxZipSize {
+		return nil, fmt.Errorf("package is %d MB; Lambda accepts at most %d MB for direct uploads", len(data)>>20, MaxZipSize>>20)
+	}
+	return data, nil
+}
+
+// PipInstallArgs returns the pip arguments that install requirements.txt into
+// the current directory as binary wheels for Lambda's Amazon Linux platform,
+// so compiled packages match the function's Python version and architecture
+// rather than the machine running the deploy
+func PipInstallArgs(runtime, architecture string) []string {
+	platform := "manylinux2014_x86_64"
+	if architecture == "arm64" {
+		platform = "manylinux2014_aarch64"
+	}
+	return []string{
+		"install", "-r", "requirements.txt", "-t", ".", "--quiet",
+		"--platform", platform,
+		"--implementation", "cp",
+		"--python-version", strings.TrimPrefix(runtime, "python"),
+		"--only-binary=:all:",
+	}
+}
+
+// CodeSha256 returns a package's hash in the form Lambda reports it
+func CodeSha256(zip []byte) string {
+	sum := sha256.Sum256(zip)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// Zip archives a directory deterministically: files are sorted and carry a
+// fixed timestamp. Hidden files and directories are skipped.
+func Zip(dir string) ([]byte, error) {
+	var paths []string
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p != dir && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Type().IsRegular() {
+			paths = append(paths, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, p := range paths {
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return nil, err
+		}
+		info, err := os.Stat(p)
+		if err != nil {
+			return nil, err
+		}
+
+		header := &zip.FileHeader{Name: filepath.ToSlash(rel), Method: zip.Deflate, Modified: zipEpoch}
+		header.SetMode(info.Mode().Perm() | 0444)
+		w, err := zw.CreateHeader(header)
+		if err != nil {
+			return nil, err
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(data); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func copyTree(src, dst string) error {
+	return filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if d.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, info.Mode().Perm())
+	})
+}
+
+// hasRequirements reports whether a requirements.txt lists any packages
+func hasRequirements(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			return true
+		}
+	}
+	return false
+}
+
+// hasNodeDependencies reports whether a package.json declares dependencies
+func hasNodeDependencies(path string) bool {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	var pkg struct {
+		Dependencies map[string]string `json:"dependencies"`
+	}
+	return json.Unmarshal(data, &pkg) == nil && len(pkg.Dependencies) > 0
+}
+
+func run(dir string, output io.Writer, name string, args ...string) error {
+	if output == nil {
+		output = io.Discard
+	}
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	cmd.Stdout = output
+	cmd.Stderr = output
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s %s failed: %w", name, strings.Join(args, " "), err)
+	}
+	return nil
+}
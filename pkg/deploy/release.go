@@ -0,0 +1,273 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/OplexTech/soloops-cli/pkg/aws"
+)
+
+// LiveAlias is the alias API Gateway invokes; generated in main.tf
+const LiveAlias = "live"
+
+// Release describes a code-only deploy of a function
+type Release struct {
+	Function      string // name or ARN
+	Alias         string
+	Package       []byte
+	HealthPath    string        // requested from the new version before it takes traffic; empty to skip
+	HealthTimeout time.Duration // how long the health check may keep failing
+
+	// Steps are the percentages of traffic shifted to the new version in
+	// turn, ending at 100. Empty moves all traffic at once.
+	Steps []int
+	// Interval is how long each step is held while Alarms are watched
+	Interval   time.Duration
+	Alarms     []string
+	CloudWatch *aws.CloudWatch
+}
+
+// ReleaseResult reports what a release did
+type ReleaseResult struct {
+	PreviousVersion string
+	Version         string
+	Unchanged       bool // the alias already serves this package
+	RolledBack      bool
+}
+
+// Run publishes the package as a new version, health checks that version
+// directly and shifts the alias to it step by step while the alarms are
+// watched. If the health check fails or an alarm fires, the alias goes back to
+// the previous version and the previous code is republished, so $LATEST and
+// the newest version (which Terraform points the alias at) hold known-good
+// code again.
+func (r *Release) Run(ctx context.Context, lambda *aws.Lambda, log func(format string, args ...interface{})) (*ReleaseResult, error) {
+	alias, err := lambda.GetAlias(ctx, r.Function, r.Alias)
+	if err != nil {
+		return nil, err
+	}
+	result := &ReleaseResult{PreviousVersion: alias.FunctionVersion}
+
+	current, err := lambda.GetFunctionConfiguration(ctx, r.Function, alias.FunctionVersion)
+	if err != nil {
+		return nil, err
+	}
+	if current.CodeSha256 == CodeSha256(r.Package) {
+		result.Version = alias.FunctionVersion
+		result.Unchanged = true
+		return result, nil
+	}
+
+	log("Uploading package (%d KB)...", (len(r.Package)+1023)/1024)
+	published, err := lambda.UpdateFunctionCode(ctx, r.Function, r.Package, true)
+	if err != nil {
+		return nil, err
+	}
+	if err := lambda.WaitForVersion(ctx, r.Function, published.Version); err != nil {
+		return nil, err
+	}
+	result.Version = published.Version
+	log("Published version %s", published.Version)
+
+	// Check the new version itself: through the alias, a weighted step would
+	// mostly reach the previous version
+	if r.HealthPath != "" {
+		log("Checking GET %s on version %s...", r.HealthPath, published.Version)
+		if err := CheckHealth(ctx, lambda, r.Function, published.Version, r.HealthPath, r.HealthTimeout); err != nil {
+			return result, r.abort(ctx, lambda, result, fmt.Errorf("health check failed: %w", err), log)
+		}
+		log("Health check passed")
+	}
+
+	steps := r.Steps
+	if len(steps) == 0 {
+		steps = []int{100}
+	}
+	for _, percent := range steps {
+		if percent < 100 {
+			routing := &aws.AliasRoutingConfig{AdditionalVersionWeights: map[string]float64{
+				published.Version: float64(percent) / 100,
+			}}
+			if _, err := lambda.UpdateAlias(ctx, r.Function, r.Alias, result.PreviousVersion, routing); err != nil {
+				return nil, r.abort(ctx, lambda, result, err, log)
+			}
+			log("Shifted %d%% of alias %s traffic to version %s", percent, r.Alias, published.Version)
+		} else {
+			if _, err := lambda.UpdateAlias(ctx, r.Function, r.Alias, published.Version, nil); err != nil {
+				return nil, r.abort(ctx, lambda, result, err, log)
+			}
+			log("Moved alias %s from version %s to %s", r.Alias, result.PreviousVersion, published.Version)
+		}
+
+		if r.Interval > 0 {
+			log("Watching %d alarms for %s...", len(r.Alarms), r.Interval)
+			if err := r.watchAlarms(ctx); err != nil {
+				return result, r.abort(ctx, lambda, result, err, log)
+			}
+		}
+	}
+	return result, nil
+}
+
+// watchAlarms holds the current step for the interval, failing as soon as a
+// watched alarm fires
+func (r *Release) watchAlarms(ctx context.Context) error {
+	deadline := time.Now().Add(r.Interval)
+	poll := r.Interval / 10
+	if poll > 15*time.Second {
+		poll = 15 * time.Second
+	}
+
+	for {
+		if len(r.Alarms) > 0 && r.CloudWatch != nil {
+			alarms, err := r.CloudWatch.DescribeAlarms(ctx, r.Alarms)
+			if err != nil {
+				return err
+			}
+			for _, alarm := range alarms {
+				if alarm.StateValue == aws.AlarmStateAlarm {
+					return fmt.Errorf("alarm %s is firing: %s", alarm.AlarmName, alarm.StateReason)
+				}
+			}
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return nil
+		}
+		wait := poll
+		if remaining < wait {
+			wait = remaining
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// abort rolls the release back after cause and returns the error to report
+func (r *Release) abort(ctx context.Context, lambda *aws.Lambda, result *ReleaseResult, cause error, log func(format string, args ...interface{})) error {
+	log("Release failed: %v", cause)
+	// Roll back even when the release was interrupted
+	if err := r.rollback(context.WithoutCancel(ctx), lambda, result.PreviousVersion, log); err != nil {
+		return fmt.Errorf("%v, and rollback failed: %w", cause, err)
+	}
+	result.RolledBack = true
+	return fmt.Errorf("rolled back to version %s: %w", result.PreviousVersion, cause)
+}
+
+func (r *Release) rollback(ctx context.Context, lambda *aws.Lambda, previous string, log func(format string, args ...interface{})) error {
+	if _, err := lambda.UpdateAlias(ctx, r.Function, r.Alias, previous, nil); err != nil {
+		return err
+	}
+	log("Moved alias %s back to version %s", r.Alias, previous)
+
+	code, err := lambda.GetFunctionCode(ctx, r.Function, previous)
+	if err != nil {
+		return err
+	}
+	restored, err := lambda.UpdateFunctionCode(ctx, r.Function, code, true)
+	if err != nil {
+		return err
+	}
+	if err := lambda.WaitForVersion(ctx, r.Function, restored.Version); err != nil {
+		return err
+	}
+	if _, err := lambda.UpdateAlias(ctx, r.Function, r.Alias, restored.Version, nil); err != nil {
+		return err
+	}
+	log("Republished the code of version %s as version %s", previous, restored.Version)
+	return nil
+}
+
+// CheckHealth invokes a function version with an API Gateway (HTTP API)
+// GET request for path until it answers with a 2xx status or timeout passes
+func CheckHealth(ctx context.Context, lambda *aws.Lambda, function, version, path string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	interval := timeout / 10
+	if interval > 2*time.Second {
+		interval = 2 * time.Second
+	}
+	event, err := healthEvent(path)
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+	for {
+		payload, err := lambda.Invoke(ctx, function, version, event)
+		if err == nil {
+			status := responseStatus(payload)
+			if status >= 200 && status < 300 {
+				return nil
+			}
+			err = fmt.Errorf("GET %s returned HTTP %d", path, status)
+		}
+		lastErr = err
+
+		select {
+		case <-ctx.Done():
+			return lastErr
+		case <-time.After(interval):
+		}
+	}
+}
+
+// healthEvent builds the payload format 2.0 event API Gateway sends for a
+// GET request
+func healthEvent(path string) ([]byte, error) {
+	path = "/" + strings.TrimPrefix(path, "/")
+	return json.Marshal(map[string]interface{}{
+		"version":         "2.0",
+		"routeKey":        "$default",
+		"rawPath":         path,
+		"rawQueryString":  "",
+		"headers":         map[string]string{"user-agent": "soloops-deploy"},
+		"isBase64Encoded": false,
+		"requestContext": map[string]interface{}{
+			"routeKey": "$default",
+			"stage":    "$default",
+			"http": map[string]string{
+				"method":    http.MethodGet,
+				"path":      path,
+				"protocol":  "HTTP/1.1",
+				"sourceIp":  "127.0.0.1",
+				"userAgent": "soloops-deploy",
+			},
+		},
+	})
+}
+
+// responseStatus reads the status code of a handler response the way API
+// Gateway does: a response without statusCode means 200
+func responseStatus(payload []byte) int {
+	var resp struct {
+		StatusCode *int `json:"statusCode"`
+	}
+	if json.Unmarshal(payload, &resp) != nil || resp.StatusCode == nil {
+		return http.StatusOK
+	}
+	return *resp.StatusCode
+}
@@ -0,0 +1,272 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package deploy ships application artifacts to provisioned blueprints
+package deploy
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/OplexTech/soloops-cli/pkg/aws"
+)
+
+// Cache-Control values for uploaded files. Documents are revalidated quickly
+// so a deploy shows up within minutes; everything else is cached for a year.
+const (
+	CacheShort = "public, max-age=300"
+	CacheLong  = "public, max-age=31536000"
+)
+
+// shortCacheExtensions are files that keep the same name across deploys
+var shortCacheExtensions = map[string]bool{
+	".html":        true,
+	".htm":         true,
+	".json":        true,
+	".xml":         true,
+	".txt":         true,
+	".webmanifest": true,
+}
+
+// contentTypes covers extensions that mime.TypeByExtension misses or gets
+// wrong on minimal systems without /etc/mime.types
+var contentTypes = map[string]string{
+	".html":        "text/html; charset=utf-8",
+	".htm":         "text/html; charset=utf-8",
+	".css":         "text/css; charset=utf-8",
+	".js":          "text/javascript; charset=utf-8",
+	".mjs":         "text/javascript; charset=utf-8",
+	".json":        "application/json",
+	".map":         "application/json",
+	".webmanifest": "application/manifest+json",
+	".xml":         "application/xml",
+	".txt":         "text/plain; charset=utf-8",
+	".svg":         "image/svg+xml",
+	".png":         "image/png",
+	".jpg":         "image/jpeg",
+	".jpeg":        "image/jpeg",
+	".gif":         "image/gif",
+	".webp":        "image/webp",
+	".avif":        "image/avif",
+	".ico":         "image/x-icon",
+	".woff":        "font/woff",
+	".woff2":       "font/woff2",
+	".ttf":         "font/ttf",
+	".otf":         "font/otf",
+	".wasm":        "application/wasm",
+	".pdf":         "application/pdf",
+	".mp4":         "video/mp4",
+	".webm":        "video/webm",
+}
+
+// ContentType returns the Content-Type for a file name
+func ContentType(name string) string {
+	ext := strings.ToLower(path.Ext(name))
+	if ct, ok := contentTypes[ext]; ok {
+		return ct
+	}
+	if ct := mime.TypeByExtension(ext); ct != "" {
+		return ct
+	}
+	return "application/octet-stream"
+}
+
+// CacheControl returns the Cache-Control header for a file name
+func CacheControl(name string) string {
+	if shortCacheExtensions[strings.ToLower(path.Ext(name))] {
+		return CacheShort
+	}
+	return CacheLong
+}
+
+// File is a local file to deploy
+type File struct {
+	Key  string // object key, always with forward slashes
+	Path string // path on disk
+	MD5  string // hex MD5 of the content, compared against S3 ETags
+	Size int64
+}
+
+// LocalFiles lists the files under dir with their hashes. Hidden files and
+// directories are skipped, except .well-known.
+func LocalFiles(dir string) ([]File, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+
+	var files []File
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p != dir && strings.HasPrefix(d.Name(), ".") && d.Name() != ".well-known" {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		sum := md5.Sum(data)
+		files = append(files, File{
+			Key:  filepath.ToSlash(rel),
+			Path: p,
+			MD5:  hex.EncodeToString(sum[:]),
+			Size: int64(len(data)),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// SitePlan is what a static site deploy will change in the bucket
+type SitePlan struct {
+	Upload    []File
+	Delete    []string
+	Unchanged int
+}
+
+// Empty reports whether the bucket already matches the local files
+func (p *SitePlan) Empty() bool {
+	return len(p.Upload) == 0 && len(p.Delete) == 0
+}
+
+// PlanSite compares local files with the bucket contents. Files whose MD5
+// matches the object's ETag are skipped; objects without a local file are
+// deleted.
+func PlanSite(local []File, remote []aws.Object) *SitePlan {
+	etags := map[string]string{}
+	for _, obj := range remote {
+		etags[obj.Key] = obj.ETag
+	}
+
+	p := &SitePlan{}
+	seen := map[string]bool{}
+	for _, f := range local {
+		seen[f.Key] = true
+		if etag, ok := etags[f.Key]; ok && strings.EqualFold(etag, f.MD5) {
+			p.Unchanged++
+			continue
+		}
+		p.Upload = append(p.Upload, f)
+	}
+	for _, obj := range remote {
+		if !seen[obj.Key] {
+			p.Delete = append(p.Delete, obj.Key)
+		}
+	}
+
+	sort.Slice(p.Upload, func(i, j int) bool { return p.Upload[i].Key < p.Upload[j].Key })
+	sort.Strings(p.Delete)
+	return p
+}
+
+// uploadWorkers bounds concurrent uploads
+const uploadWorkers = 8
+
+// Apply uploads and deletes the planned objects. log is called once per
+// object as it completes.
+func (p *SitePlan) Apply(ctx context.Context, s3 *aws.S3, bucket string, log func(action, key string)) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	fail := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if firstErr == nil {
+			firstErr = err
+			cancel()
+		}
+	}
+
+	jobs := make(chan File)
+	for i := 0; i < uploadWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for f := range jobs {
+				body, err := os.ReadFile(f.Path)
+				if err == nil {
+					err = s3.PutObject(ctx, aws.PutObjectInput{
+						Bucket:       bucket,
+						Key:          f.Key,
+						Body:         body,
+						ContentType:  ContentType(f.Key),
+						CacheControl: CacheControl(f.Key),
+					})
+				}
+				if err != nil {
+					fail(err)
+					continue
+				}
+				mu.Lock()
+				log("upload", f.Key)
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, f := range p.Upload {
+		if ctx.Err() != nil {
+			break
+		}
+		jobs <- f
+	}
+	close(jobs)
+	wg.Wait()
+	if firstErr != nil {
+		return firstErr
+	}
+
+	// Stale objects go last so a failed upload never leaves the site with
+	// pages removed but not replaced
+	for _, key := range p.Delete {
+		if err := s3.DeleteObject(ctx, bucket, key); err != nil {
+			return err
+		}
+		log("delete", key)
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package diff renders unified diffs between text files
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// contextLines is the number of unchanged lines shown around each change
+const contextLines = 3
+
+type opKind int
+
+const (
+	opEqual opKind = iota
+	opDelete
+	opInsert
+)
+
+type op struct {
+	kind opKind
+	line string
+}
+
+// Unified returns a unified diff turning oldText into newText, or an empty
+// string when they are identical
+func Unified(oldName, newName, oldText, newText string) string {
+	if oldText == newText {
+		return ""
+	}
+
+	ops := compute(splitLines(oldText), splitLines(newText))
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", oldName, newName)
+
+	for _, h := range hunks(ops) {
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n", span(h.oldStart, h.oldLines), span(h.newStart, h.newLines))
+		for _, o := range ops[h.from:h.to] {
+			switch o.kind {
+			case opEqual:
+				out.WriteString(" " + o.line + "\n")
+			case opDelete:
+				out.WriteString("-" + o.line + "\n")
+			case opInsert:
+				out.WriteString("+" + o.line + "\n")
+			}
+		}
+	}
+
+	return out.String()
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// compute builds an edit script from the longest common subsequence of the
+// two line slices. Manifests and generated files are small, so the quadratic
+// table is fine.
+func compute(a, b []string) []op {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	ops := make([]op, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, op{opEqual, a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, op{opDelete, a[i]})
+			i++
+		default:
+			ops = append(ops, op{opInsert, b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, op{opDelete, a[i]})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, op{opInsert, b[j]})
+	}
+
+	return ops
+}
+
+type hunk struct {
+	from, to           int
+	oldStart, oldLines int
+	newStart, newLines int
+}
+
+// hunks groups changes with their surrounding context, merging groups whose
+// context would overlap
+func hunks(ops []op) []hunk {
+	var result []hunk
+
+	for i := 0; i < len(ops); {
+		if ops[i].kind == opEqual {
+			i++
+			continue
+		}
+
+		from := i - contextLines
+		if from < 0 {
+			from = 0
+		}
+
+		// Extend while the next change is within two context windows
+		to := i
+		for to < len(ops) {
+			if ops[to].kind != opEqual {
+				to++
+				continue
+			}
+			run := to
+			for run < len(ops) && ops[run].kind == opEqual {
+				run++
+			}
+			if run == len(ops) || run-to > 2*contextLines {
+				to += min(contextLines, run-to)
+				break
+			}
+			to = run
+		}
+
+		h := hunk{from: from, to: to}
+		oldLine, newLine := 1, 1
+		for _, o := range ops[:from] {
+			if o.kind != opInsert {
+				oldLine++
+			}
+			if o.kind != opDelete {
+				newLine++
+			}
+		}
+		h.oldStart, h.newStart = oldLine, newLine
+		for _, o := range ops[from:to] {
+			if o.kind != opInsert {
+				h.oldLines++
+			}
+			if o.kind != opDelete {
+				h.newLines++
+			}
+		}
+		if h.oldLines == 0 {
+			h.oldStart--
+		}
+		if h.newLines == 0 {
+			h.newStart--
+		}
+
+		result = append(result, h)
+		i = to
+	}
+
+	return result
+}
+
+func span(start, lines int) string {
+	if lines == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, lines)
+}
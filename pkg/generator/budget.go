@@ -14,45 +14,43 @@
 
 package generator
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/OplexTech/soloops-cli/pkg/hcl"
+)
 
 func (g *Generator) generateBudget() error {
+	file := hcl.NewFile()
+	body := file.Body()
+
 	if g.Config.Cloud != "aws" {
-		return g.writeFile("budget.tf", "# Budget alerts currently only supported for AWS\n")
+		body.Comment("Budget alerts currently only supported for AWS")
+		return g.writeFile("budget.tf", file)
 	}
 
-	content := fmt.Sprintf(`# Budget alert
-resource "aws_budgets_budget" "monthly" {
-  name         = "${var.project_name}-${var.environment}-monthly"
-  budget_type  = "COST"
-  limit_amount = "%.2f"
-  limit_unit   = "USD"
-  time_unit    = "MONTHLY"
-
-  notification {
-    comparison_operator        = "GREATER_THAN"
-    threshold                  = 80
-    threshold_type             = "PERCENTAGE"
-    notification_type          = "ACTUAL"
-    subscriber_email_addresses = []
-  }
-
-  notification {
-    comparison_operator        = "GREATER_THAN"
-    threshold                  = 100
-    threshold_type             = "PERCENTAGE"
-    notification_type          = "ACTUAL"
-    subscriber_email_addresses = []
-  }
-
-  cost_filter {
-    name = "TagKeyValue"
-    values = [
-      "Project$${var.project_name}",
-    ]
-  }
-}
-`, g.Env.BudgetUSD)
+	body.Comment("Budget alert")
+	budget := body.Block("resource", "aws_budgets_budget", "monthly")
+	budget.Set("name", hcl.String(g.physicalName("aws_budgets_budget", "", "monthly")))
+	budget.Set("budget_type", hcl.String("COST"))
+	budget.Set("limit_amount", hcl.String(fmt.Sprintf("%.2f", g.Env.BudgetUSD)))
+	budget.Set("limit_unit", hcl.String("USD"))
+	budget.Set("time_unit", hcl.String("MONTHLY"))
+
+	for _, threshold := range []float64{80, 100} {
+		budget.Newline()
+		notification := budget.Block("notification")
+		notification.Set("comparison_operator", hcl.String("GREATER_THAN"))
+		notification.Set("threshold", hcl.Number(threshold))
+		notification.Set("threshold_type", hcl.String("PERCENTAGE"))
+		notification.Set("notification_type", hcl.String("ACTUAL"))
+		notification.Set("subscriber_email_addresses", hcl.List())
+	}
+
+	budget.Newline()
+	filter := budget.Block("cost_filter")
+	filter.Set("name", hcl.String("TagKeyValue"))
+	filter.Set("values", hcl.List(hcl.String("Project${var.project_name}")))
 
-	return g.writeFile("budget.tf", content)
+	return g.writeFile("budget.tf", file)
 }
@@ -0,0 +1,187 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ChecksumFile, in the generated directory, records the SHA-256 of every
+// file the last generate wrote in sha256sum format, so files edited by hand
+// are detected
+const ChecksumFile = ".soloops-files.sha256"
+
+// Change is a difference between a generated file and the one on disk
+type Change struct {
+	Path    string
+	Current []byte // nil when the file does not exist
+	Content []byte // nil when the file is no longer generated
+	Edited  bool   // changed by hand since the last generate
+}
+
+// EditedError is returned by Generate when files it would overwrite or
+// remove were edited by hand
+type EditedError struct {
+	Changes []Change
+}
+
+func (e *EditedError) Error() string {
+	paths := make([]string, len(e.Changes))
+	for i, change := range e.Changes {
+		paths[i] = change.Path
+	}
+	return fmt.Sprintf("generated files were edited by hand since the last generate: %s (move the edits to %s/ or use --force to overwrite them)",
+		strings.Join(paths, ", "), OverridesDir)
+}
+
+// Edited returns the changes to files edited by hand
+func Edited(changes []Change) []Change {
+	var edited []Change
+	for _, change := range changes {
+		if change.Edited {
+			edited = append(edited, change)
+		}
+	}
+	return edited
+}
+
+// Changes renders the files and compares them with Dir, without writing
+// anything. Files recorded by the last generate that are no longer generated
+// are included as removals.
+func (g *Generator) Changes() ([]Change, error) {
+	files, err := g.render()
+	if err != nil {
+		return nil, err
+	}
+	recorded, err := g.readChecksums()
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	for path := range recorded {
+		if _, ok := files[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	var changes []Change
+	for _, path := range paths {
+		current, err := g.FS.ReadFile(path)
+		if os.IsNotExist(err) {
+			current = nil
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		content := files[path]
+		if current == nil && content == nil || content != nil && bytes.Equal(current, content) {
+			continue
+		}
+		sum, ok := recorded[path]
+		changes = append(changes, Change{
+			Path:    path,
+			Current: current,
+			Content: content,
+			Edited:  current != nil && ok && checksum(current) != sum,
+		})
+	}
+	return changes, nil
+}
+
+// apply writes the change to disk, removing the file and its directory if
+// left empty when it is no longer generated
+func (c Change) apply(fsys FS, root string) error {
+	if c.Content == nil {
+		if err := fsys.Remove(c.Path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", c.Path, err)
+		}
+		if dir := filepath.Dir(c.Path); dir != root {
+			_ = fsys.Remove(dir) // only succeeds when empty
+		}
+		return nil
+	}
+
+	if err := fsys.MkdirAll(filepath.Dir(c.Path), 0755); err != nil {
+		return err
+	}
+	if err := fsys.WriteFile(c.Path, c.Content, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", c.Path, err)
+	}
+	return nil
+}
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// readChecksums returns the checksums recorded by the last generate, keyed
+// by path
+func (g *Generator) readChecksums() (map[string]string, error) {
+	path := filepath.Join(g.Dir, ChecksumFile)
+	data, err := g.FS.ReadFile(path)
+	if os.IsNotExist(err) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	recorded := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		sum, rel, ok := strings.Cut(scanner.Text(), "  ")
+		if !ok {
+			continue
+		}
+		recorded[filepath.Join(g.Dir, filepath.FromSlash(rel))] = sum
+	}
+	return recorded, nil
+}
+
+func (g *Generator) writeChecksums() error {
+	paths := make([]string, 0, len(g.files))
+	for path := range g.files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var out strings.Builder
+	for _, path := range paths {
+		rel, err := filepath.Rel(g.Dir, path)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&out, "%s  %s\n", checksum(g.files[path]), filepath.ToSlash(rel))
+	}
+	path := filepath.Join(g.Dir, ChecksumFile)
+	if err := g.FS.WriteFile(path, []byte(out.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
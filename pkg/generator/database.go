@@ -0,0 +1,171 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"github.com/OplexTech/soloops-cli/pkg/config"
+	"github.com/OplexTech/soloops-cli/pkg/hcl"
+)
+
+// Database blueprints are generated by db_type: postgres and mysql as an RDS
+// instance, aurora_serverless_v2 as an Aurora PostgreSQL cluster, and
+// dynamodb as an on-demand table. RDS and Aurora keep their master password
+// in Secrets Manager and sit behind their own security group.
+
+// databaseInputs passes a database module the physical names derived from
+// the blueprint
+func (g *Generator) databaseInputs(module *hcl.Body, name string, bp config.Blueprint) {
+	switch bp.DBType {
+	case "dynamodb":
+		module.Set("table_name", hcl.String(g.physicalName("aws_dynamodb_table", name, name)))
+	case "aurora_serverless_v2":
+		module.Set("identifier", hcl.String(g.physicalName("aws_rds_cluster", name, name)))
+		module.Set("instance_identifier", hcl.String(g.physicalName("aws_rds_cluster_instance", name, name+"-1")))
+		g.finalSnapshotInput(module, name, bp)
+	case "postgres", "mysql":
+		module.Set("identifier", hcl.String(g.physicalName("aws_db_instance", name, name)))
+		module.Set("engine", hcl.String(bp.DBType))
+		g.finalSnapshotInput(module, name, bp)
+	}
+}
+
+// finalSnapshotInput names the snapshot taken when a protected RDS or Aurora
+// database is deleted; DynamoDB tables have no final snapshot
+func (g *Generator) finalSnapshotInput(module *hcl.Body, name string, bp config.Blueprint) {
+	if bp.Protect {
+		module.Set("final_snapshot_identifier", hcl.String(g.physicalName("aws_db_snapshot", name, name+"-final")))
+	}
+}
+
+// databaseOutputs exposes a database's table name or endpoint at the root
+func (g *Generator) databaseOutputs(body *hcl.Body, name string, bp config.Blueprint) {
+	switch bp.DBType {
+	case "dynamodb":
+		output(body, name+"_table_name", "DynamoDB table name for "+name, orNA(name, "table_name"))
+	case "aurora_serverless_v2", "postgres", "mysql":
+		output(body, name+"_db_endpoint", "Database endpoint for "+name, orNA(name, "endpoint"))
+	}
+}
+
+func dynamoDBModule(m *module, protect bool) {
+	variable(m.variables.Body(), "table_name", "Name of the DynamoDB table", typeString, nil)
+
+	table := resource(m.main.Body(), "DynamoDB table", "aws_dynamodb_table", "this")
+	table.Set("name", hcl.Ref("var", "table_name"))
+	table.Set("billing_mode", hcl.String("PAY_PER_REQUEST"))
+	table.Set("hash_key", hcl.String("id"))
+	table.Set("deletion_protection_enabled", hcl.Bool(protect))
+	table.Newline()
+	attribute := table.Block("attribute")
+	attribute.Set("name", hcl.String("id"))
+	attribute.Set("type", hcl.String("S"))
+	table.Newline()
+	table.Block("point_in_time_recovery").Set("enabled", hcl.Bool(true))
+	lifecycle(table, protect)
+
+	outputs := m.outputs.Body()
+	output(outputs, "table_name", "DynamoDB table name", hcl.Ref("aws_dynamodb_table", "this", "name"))
+	output(outputs, "table_arn", "DynamoDB table ARN", hcl.Ref("aws_dynamodb_table", "this", "arn"))
+}
+
+func auroraModule(m *module, protect bool) {
+	vars := m.variables.Body()
+	variable(vars, "identifier", "Identifier of the cluster", typeString, nil)
+	variable(vars, "instance_identifier", "Identifier of the serverless instance", typeString, nil)
+	if protect {
+		variable(vars, "final_snapshot_identifier", "Identifier of the snapshot taken when the cluster is destroyed", typeString, nil)
+	}
+
+	body := m.main.Body()
+	cluster := resource(body, "Aurora Serverless v2 cluster", "aws_rds_cluster", "this")
+	cluster.Set("cluster_identifier", hcl.Ref("var", "identifier"))
+	cluster.Set("engine", hcl.String("aurora-postgresql"))
+	cluster.Set("engine_mode", hcl.String("provisioned"))
+	cluster.Set("master_username", hcl.String("soloops"))
+	databaseSecurity(cluster, protect)
+	cluster.Newline()
+	scaling := cluster.Block("serverlessv2_scaling_configuration")
+	scaling.Set("min_capacity", hcl.Number(0.5))
+	scaling.Set("max_capacity", hcl.Number(2))
+	lifecycle(cluster, protect)
+
+	instance := resource(body, "", "aws_rds_cluster_instance", "this")
+	instance.Set("identifier", hcl.Ref("var", "instance_identifier"))
+	instance.Set("cluster_identifier", hcl.Ref("aws_rds_cluster", "this", "id"))
+	instance.Set("instance_class", hcl.String("db.serverless"))
+	instance.Set("engine", hcl.Ref("aws_rds_cluster", "this", "engine"))
+	lifecycle(instance, protect)
+	databaseNetwork(body)
+
+	rdsOutputs(m.outputs.Body(), "aws_rds_cluster")
+}
+
+func rdsModule(m *module, protect bool) {
+	vars := m.variables.Body()
+	variable(vars, "identifier", "Identifier of the instance", typeString, nil)
+	variable(vars, "engine", "Database engine: postgres or mysql", typeString, nil)
+	if protect {
+		variable(vars, "final_snapshot_identifier", "Identifier of the snapshot taken when the instance is destroyed", typeString, nil)
+	}
+
+	body := m.main.Body()
+	db := resource(body, "RDS instance", "aws_db_instance", "this")
+	db.Set("identifier", hcl.Ref("var", "identifier"))
+	db.Set("engine", hcl.Ref("var", "engine"))
+	db.Set("instance_class", hcl.String("db.t4g.micro"))
+	db.Set("allocated_storage", hcl.Number(20))
+	db.Set("username", hcl.String("soloops"))
+	databaseSecurity(db, protect)
+	lifecycle(db, protect)
+	databaseNetwork(body)
+
+	rdsOutputs(m.outputs.Body(), "aws_db_instance")
+}
+
+// databaseSecurity sets the attributes shared by RDS clusters and instances.
+// A protected database keeps a final snapshot when it is eventually
+// destroyed; unprotected databases are dropped without one.
+func databaseSecurity(db *hcl.Body, protect bool) {
+	db.Set("manage_master_user_password", hcl.Bool(true))
+	db.Set("storage_encrypted", hcl.Bool(true))
+	db.Set("vpc_security_group_ids", hcl.List(hcl.Ref("aws_security_group", "this", "id")))
+	db.Set("deletion_protection", hcl.Bool(protect))
+	db.Set("skip_final_snapshot", hcl.Bool(!protect))
+	if protect {
+		db.Set("final_snapshot_identifier", hcl.Ref("var", "final_snapshot_identifier"))
+	}
+}
+
+// rdsOutputs declares the outputs shared by RDS clusters and instances,
+// including those web_api blueprints read when they use the database
+func rdsOutputs(outputs *hcl.Body, typ string) {
+	output(outputs, "endpoint", "Database endpoint", hcl.Ref(typ, "this", "endpoint"))
+	output(outputs, "port", "Database port", hcl.Ref(typ, "this", "port"))
+	output(outputs, "secret_arn", "ARN of the Secrets Manager secret holding the master password",
+		hcl.Ref(typ, "this", "master_user_secret[0]", "secret_arn"))
+	output(outputs, "security_group_id", "Security group guarding the database", hcl.Ref("aws_security_group", "this", "id"))
+}
+
+// databaseNetwork adds the security group guarding a database, which
+// functions using it are allowed into
+func databaseNetwork(body *hcl.Body) {
+	body.Newline()
+	body.Block("data", "aws_vpc", "default").Set("default", hcl.Bool(true))
+
+	sg := resource(body, "Functions listed as users of the database are allowed into this group", "aws_security_group", "this")
+	sg.Set("name", hcl.Template(hcl.Ref("var", "identifier"), hcl.String("-db")))
+	sg.Set("description", hcl.String("Access to the database"))
+	sg.Set("vpc_id", hcl.Ref("data", "aws_vpc", "default", "id"))
+}
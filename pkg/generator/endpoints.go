@@ -0,0 +1,130 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"github.com/OplexTech/soloops-cli/pkg/config"
+	"github.com/OplexTech/soloops-cli/pkg/hcl"
+)
+
+// vpcEndpoint is an AWS service a function in the default VPC calls
+type vpcEndpoint struct {
+	service string
+	gateway bool // a route table entry instead of network interfaces
+}
+
+// vpcEndpoints lists the endpoints in the order they are generated
+var vpcEndpoints = []vpcEndpoint{
+	{service: "secretsmanager"},
+	{service: "dynamodb", gateway: true},
+	{service: "s3", gateway: true},
+	{service: "lambda"},
+}
+
+// vpcServices returns the services the environment's functions in the
+// default VPC call: Secrets Manager for the database password and DynamoDB,
+// S3 and Lambda for the other blueprints they use. Without a NAT gateway the
+// VPC only reaches them through endpoints.
+func (g *Generator) vpcServices() map[string]bool {
+	services := map[string]bool{}
+	for _, bp := range g.Env.Blueprints {
+		if bp.Kind() != config.KindWebAPI || !g.usesDatabase(bp) {
+			continue
+		}
+		for _, name := range bp.Uses {
+			used := g.Env.Blueprints[name]
+			switch {
+			case used.Relational():
+				services["secretsmanager"] = true
+			case used.Kind() == config.KindDatabase:
+				services["dynamodb"] = true
+			case used.Kind() == config.KindStaticSite:
+				services["s3"] = true
+			case used.Kind() == config.KindWebAPI:
+				services["lambda"] = true
+			}
+		}
+	}
+	return services
+}
+
+// usesDatabase reports whether a web_api uses a relational database, which
+// places its function in the default VPC
+func (g *Generator) usesDatabase(bp config.Blueprint) bool {
+	for _, name := range bp.Uses {
+		if g.Env.Blueprints[name].Relational() {
+			return true
+		}
+	}
+	return false
+}
+
+// generateEndpoints writes endpoints.tf with the VPC endpoints of the
+// services functions in the default VPC call, when there are any
+func (g *Generator) generateEndpoints() error {
+	services := g.vpcServices()
+	if g.Config.Cloud != "aws" || len(services) == 0 {
+		return nil
+	}
+
+	file := hcl.NewFile()
+	body := file.Body()
+	body.Comment("VPC endpoints for the functions in the default VPC, which has no")
+	body.Comment("route to AWS APIs without a NAT gateway")
+	vpcID := hcl.Ref("data", "aws_vpc", "default", "id")
+
+	body.Newline()
+	body.Block("data", "aws_vpc", "default").Set("default", hcl.Bool(true))
+
+	body.Newline()
+	filter := body.Block("data", "aws_subnets", "default").Block("filter")
+	filter.Set("name", hcl.String("vpc-id"))
+	filter.Set("values", hcl.List(vpcID))
+
+	body.Newline()
+	body.Block("data", "aws_route_tables", "default").Set("vpc_id", vpcID)
+
+	sg := resource(body, "", "aws_security_group", "vpc_endpoints")
+	sg.Set("name", hcl.String(g.physicalName("aws_security_group", "", "vpc-endpoints")))
+	sg.Set("description", hcl.String("HTTPS from the VPC to the interface endpoints"))
+	sg.Set("vpc_id", vpcID)
+
+	ingress := resource(body, "", "aws_vpc_security_group_ingress_rule", "vpc_endpoints")
+	ingress.Set("security_group_id", hcl.Ref("aws_security_group", "vpc_endpoints", "id"))
+	ingress.Set("cidr_ipv4", hcl.Ref("data", "aws_vpc", "default", "cidr_block"))
+	ingress.Set("ip_protocol", hcl.String("tcp"))
+	ingress.Set("from_port", hcl.Number(443))
+	ingress.Set("to_port", hcl.Number(443))
+
+	for _, endpoint := range vpcEndpoints {
+		if !services[endpoint.service] {
+			continue
+		}
+		e := resource(body, "", "aws_vpc_endpoint", endpoint.service)
+		e.Set("vpc_id", vpcID)
+		e.Set("service_name", hcl.Template(hcl.String("com.amazonaws."), hcl.Ref("var", "region"), hcl.String("."+endpoint.service)))
+		if endpoint.gateway {
+			e.Set("vpc_endpoint_type", hcl.String("Gateway"))
+			e.Set("route_table_ids", hcl.Ref("data", "aws_route_tables", "default", "ids"))
+			continue
+		}
+		e.Set("vpc_endpoint_type", hcl.String("Interface"))
+		e.Set("subnet_ids", hcl.Ref("data", "aws_subnets", "default", "ids"))
+		e.Set("security_group_ids", hcl.List(hcl.Ref("aws_security_group", "vpc_endpoints", "id")))
+		e.Set("private_dns_enabled", hcl.Bool(true))
+	}
+
+	return g.writeFile("endpoints.tf", file)
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"io/fs"
+	"os"
+	"sort"
+)
+
+// FS is the filesystem the generator reads and writes infra/ through
+type FS interface {
+	ReadFile(name string) ([]byte, error)
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+	MkdirAll(path string, perm fs.FileMode) error
+	Remove(name string) error
+	ReadDir(name string) ([]fs.DirEntry, error)
+}
+
+// OS is the real filesystem
+var OS FS = osFS{}
+
+type osFS struct{}
+
+func (osFS) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
+
+func (osFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return os.WriteFile(name, data, perm)
+}
+
+func (osFS) MkdirAll(path string, perm fs.FileMode) error {
+	return os.MkdirAll(path, perm)
+}
+
+func (osFS) Remove(name string) error {
+	return os.Remove(name)
+}
+
+func (osFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	return os.ReadDir(name)
+}
+
+// Overlay keeps writes and removals in memory on top of a base filesystem,
+// so generating can be previewed without touching the disk
+type Overlay struct {
+	Base FS
+
+	files   map[string][]byte
+	removed map[string]bool
+}
+
+// NewOverlay creates an empty overlay on base
+func NewOverlay(base FS) *Overlay {
+	return &Overlay{Base: base, files: map[string][]byte{}, removed: map[string]bool{}}
+}
+
+// ReadFile reads a file written to the overlay, or from the base
+func (o *Overlay) ReadFile(name string) ([]byte, error) {
+	if data, ok := o.files[name]; ok {
+		return data, nil
+	}
+	if o.removed[name] {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return o.Base.ReadFile(name)
+}
+
+// WriteFile records a write in memory
+func (o *Overlay) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	o.files[name] = append([]byte(nil), data...)
+	delete(o.removed, name)
+	return nil
+}
+
+// MkdirAll does nothing; directories are implied by the files written
+func (o *Overlay) MkdirAll(path string, perm fs.FileMode) error {
+	return nil
+}
+
+// Remove records the removal of a file that exists in the overlay or base
+func (o *Overlay) Remove(name string) error {
+	if _, ok := o.files[name]; ok {
+		delete(o.files, name)
+	} else if _, err := o.Base.ReadFile(name); err != nil {
+		return err
+	}
+	o.removed[name] = true
+	return nil
+}
+
+// ReadDir lists a directory of the base. Only sources such as overrides/
+// are listed, which generating never writes, so the overlay adds nothing.
+func (o *Overlay) ReadDir(name string) ([]fs.DirEntry, error) {
+	return o.Base.ReadDir(name)
+}
+
+// Paths returns the files written or removed, in order
+func (o *Overlay) Paths() []string {
+	paths := make([]string, 0, len(o.files)+len(o.removed))
+	for name := range o.files {
+		paths = append(paths, name)
+	}
+	for name := range o.removed {
+		paths = append(paths, name)
+	}
+	sort.Strings(paths)
+	return paths
+}
@@ -16,54 +16,193 @@ package generator
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 
 	"github.com/OplexTech/soloops-cli/pkg/config"
+	"github.com/OplexTech/soloops-cli/pkg/hcl"
+	"github.com/OplexTech/soloops-cli/pkg/naming"
 )
 
 // Generator generates Terraform code from SoloOps configuration
 type Generator struct {
 	Config *config.Config
-	Env    *config.Environment
+
+	// Env is the environment generated; nil for a generator from NewModules
+	Env *config.Environment
+
+	// Force overwrites generated files that were edited by hand
+	Force bool
+
+	// Dir is the directory the files are generated into, infra by default
+	Dir string
+
+	// FS is where Dir is read and written, the disk by default
+	FS FS
+
+	// Overrides is the directory of hand-written Terraform copied into Dir,
+	// the overrides directory next to the manifest
+	Overrides string
+
+	// SharedModules is a directory of modules shared with other environments,
+	// such as infra/modules for infra/<env>. When set, main.tf sources the
+	// modules from there and a generator from NewModules writes them.
+	SharedModules string
+
+	names []naming.Name
+	files map[string][]byte
 }
 
 // New creates a new Generator instance
 func New(cfg *config.Config, env *config.Environment) *Generator {
 	return &Generator{
-		Config: cfg,
-		Env:    env,
+		Config:    cfg,
+		Env:       env,
+		Dir:       "infra",
+		FS:        OS,
+		Overrides: OverridesDir,
 	}
 }
 
-// Generate creates Terraform files in the infra/ directory
+// NewModules creates a Generator for the modules shared by environments
+// generated side by side: the local modules every environment's blueprints
+// use, written to infra/modules with the overrides under overrides/modules
+func NewModules(cfg *config.Config) *Generator {
+	return &Generator{
+		Config:    cfg,
+		Dir:       filepath.Join("infra", "modules"),
+		FS:        OS,
+		Overrides: OverridesDir,
+	}
+}
+
+// Validate checks what only generating reveals: blueprints deriving the same
+// physical names and terraform patches of resources that do not exist
+func (g *Generator) Validate() error {
+	if err := naming.Conflicts(g.Names()); err != nil {
+		return err
+	}
+	return g.checkPatches()
+}
+
+// Generate creates Terraform files in Dir. Files edited by
+// hand since the last generate are only overwritten with Force; files no
+// longer generated are removed.
 func (g *Generator) Generate() error {
-	// Create infra directory
-	if err := os.MkdirAll("infra", 0755); err != nil {
-		return fmt.Errorf("failed to create infra directory: %w", err)
+	changes, err := g.Changes()
+	if err != nil {
+		return err
+	}
+	if edited := Edited(changes); len(edited) > 0 && !g.Force {
+		return &EditedError{Changes: edited}
+	}
+
+	if len(changes) == 0 && len(g.files) == 0 {
+		return nil // nothing generated now or before, e.g. no local modules
 	}
 
+	if err := g.FS.MkdirAll(g.Dir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s directory: %w", g.Dir, err)
+	}
+	for _, change := range changes {
+		if err := change.apply(g.FS, g.Dir); err != nil {
+			return err
+		}
+	}
+	return g.writeChecksums()
+}
+
+// render builds every file in memory, keyed by path
+func (g *Generator) render() (map[string][]byte, error) {
+	if g.Env == nil {
+		return g.renderModules()
+	}
+	if err := g.Validate(); err != nil {
+		return nil, err
+	}
+	g.files = map[string][]byte{}
+
 	// Generate main files
 	if err := g.generateProvider(); err != nil {
-		return err
+		return nil, err
 	}
 	if err := g.generateVariables(); err != nil {
-		return err
+		return nil, err
+	}
+	if err := g.generateSecrets(); err != nil {
+		return nil, err
+	}
+	if err := g.generateModules(); err != nil {
+		return nil, err
 	}
 	if err := g.generateMain(); err != nil {
-		return err
+		return nil, err
+	}
+	if err := g.generateEndpoints(); err != nil {
+		return nil, err
+	}
+	if err := g.generateMoved(); err != nil {
+		return nil, err
 	}
 	if err := g.generateBudget(); err != nil {
-		return err
+		return nil, err
 	}
 	if err := g.generateOutputs(); err != nil {
-		return err
+		return nil, err
+	}
+	if err := g.copyOverrides(); err != nil {
+		return nil, err
+	}
+
+	return g.files, nil
+}
+
+// renderModules builds the shared modules of every environment
+func (g *Generator) renderModules() (map[string][]byte, error) {
+	g.files = map[string][]byte{}
+	if err := g.generateModules(); err != nil {
+		return nil, err
 	}
+	if err := g.copyOverrides(); err != nil {
+		return nil, err
+	}
+	return g.files, nil
+}
 
+// writeFile adds a file under Dir to the output
+func (g *Generator) writeFile(filename string, file *hcl.File) error {
+	path := filepath.Join(g.Dir, filename)
+	if _, ok := g.files[path]; ok {
+		return fmt.Errorf("%s is generated twice", path)
+	}
+	g.files[path] = file.Bytes()
 	return nil
 }
 
-func (g *Generator) writeFile(filename, content string) error {
-	path := filepath.Join("infra", filename)
-	return os.WriteFile(path, []byte(content), 0644)
+// resource appends a resource block to body after a blank line, preceded by
+// comment when it is not empty, and returns the block's body
+func resource(body *hcl.Body, comment, typ, name string) *hcl.Body {
+	body.Newline()
+	if comment != "" {
+		body.Comment(comment)
+	}
+	return body.Block("resource", typ, name)
+}
+
+// physicalName derives the cloud name of a blueprint's resource from the
+// project, environment and suffix, and records it for conflict checks
+func (g *Generator) physicalName(resourceType, blueprint, suffix string) string {
+	derive := naming.Physical
+	if g.Config.AccountSuffix() {
+		derive = naming.PhysicalUnique
+	}
+	name := derive(resourceType, g.Config.Project, g.Env.Name, suffix)
+	g.names = append(g.names, naming.Name{Blueprint: blueprint, ResourceType: resourceType, Value: name})
+	return name
+}
+
+// Names returns the physical names of the resources generated for the
+// environment's blueprints
+func (g *Generator) Names() []naming.Name {
+	g.mainFile()
+	return g.names
 }
@@ -16,254 +16,161 @@ package generator
 
 import (
 	"fmt"
-	"strings"
+	"sort"
+
+	"github.com/OplexTech/soloops-cli/pkg/config"
+	"github.com/OplexTech/soloops-cli/pkg/hcl"
 )
 
 func (g *Generator) generateMain() error {
-	var resources strings.Builder
-
-	resources.WriteString("# Generated by SoloOps\n")
-	resources.WriteString(fmt.Sprintf("# Project: %s\n", g.Config.Project))
-	resources.WriteString(fmt.Sprintf("# Environment: %s\n\n", g.Env.Name))
-
-	// Generate resources for each blueprint
-	for name, blueprint := range g.Env.Blueprints {
-		resources.WriteString(fmt.Sprintf("# Blueprint: %s\n", name))
-
-		// Determine blueprint type and generate accordingly
-		if blueprint.Runtime != "" || blueprint.Ingress != "" {
-			// Web API blueprint
-			resources.WriteString(g.generateWebAPI(name, blueprint))
-		} else if blueprint.Domain != "" {
-			// Static site blueprint
-			resources.WriteString(g.generateStaticSite(name, blueprint))
-		} else if blueprint.DBType != "" {
-			// Database blueprint
-			resources.WriteString(g.generateDatabase(name, blueprint))
-		}
-
-		resources.WriteString("\n")
-	}
-
-	return g.writeFile("main.tf", resources.String())
+	return g.writeFile("main.tf", g.mainFile())
 }
 
-func (g *Generator) generateWebAPI(name string, bp interface{}) string {
-	if g.Config.Cloud != "aws" {
-		return "# Web API blueprint currently only supports AWS\n"
-	}
+// mainFile builds main.tf, which instantiates a module per blueprint, and
+// records the physical name of every resource
+func (g *Generator) mainFile() *hcl.File {
+	g.names = nil
+	file := hcl.NewFile()
+	body := file.Body()
 
-	return fmt.Sprintf(`
-# Lambda function for %s
-resource "aws_lambda_function" "%s" {
-  function_name = "${var.project_name}-${var.environment}-%s"
-  role          = aws_iam_role.%s_lambda_role.arn
-  handler       = "index.handler"
-  runtime       = "nodejs18.x"
-  filename      = "lambda_placeholder.zip"
+	body.Comment("Generated by SoloOps")
+	body.Comment(fmt.Sprintf("Project: %s", g.Config.Project))
+	body.Comment(fmt.Sprintf("Environment: %s", g.Env.Name))
 
-  environment {
-    variables = {
-      ENVIRONMENT = var.environment
-    }
-  }
-}
-
-resource "aws_iam_role" "%s_lambda_role" {
-  name = "${var.project_name}-${var.environment}-%s-lambda-role"
-
-  assume_role_policy = jsonencode({
-    Version = "2012-10-17"
-    Statement = [{
-      Action = "sts:AssumeRole"
-      Effect = "Allow"
-      Principal = {
-        Service = "lambda.amazonaws.com"
-      }
-    }]
-  })
-}
-
-resource "aws_iam_role_policy_attachment" "%s_lambda_policy" {
-  role       = aws_iam_role.%s_lambda_role.name
-  policy_arn = "arn:aws:iam::aws:policy/service-role/AWSLambdaBasicExecutionRole"
-}
+	// Bucket names end in the account ID to make them globally unique
+	if g.Config.Cloud == "aws" && g.Config.AccountSuffix() && g.hasKind(config.KindStaticSite) {
+		body.Newline()
+		body.Block("data", "aws_caller_identity", "current")
+	}
 
-# API Gateway
-resource "aws_apigatewayv2_api" "%s" {
-  name          = "${var.project_name}-${var.environment}-%s"
-  protocol_type = "HTTP"
-}
+	// Generate resources for each blueprint, after the blueprints it uses
+	for _, name := range g.blueprintOrder() {
+		blueprint := g.Env.Blueprints[name]
+		body.Newline()
+		body.Comment(fmt.Sprintf("Blueprint: %s", name))
 
-resource "aws_apigatewayv2_integration" "%s" {
-  api_id           = aws_apigatewayv2_api.%s.id
-  integration_type = "AWS_PROXY"
-  integration_uri  = aws_lambda_function.%s.invoke_arn
-}
+		if g.Config.Cloud != "aws" {
+			body.Comment("Blueprints currently only support AWS")
+			continue
+		}
+		moduleName := blueprintModule(name, blueprint)
+		if moduleName == "" {
+			body.Comment(fmt.Sprintf("Unsupported db_type %q", blueprint.DBType))
+			continue
+		}
 
-resource "aws_apigatewayv2_route" "%s" {
-  api_id    = aws_apigatewayv2_api.%s.id
-  route_key = "$default"
-  target    = "integrations/${aws_apigatewayv2_integration.%s.id}"
-}
+		source, version := g.moduleSource(moduleName)
+		module := body.Block("module", name)
+		module.Set("source", hcl.String(source))
+		if version != "" {
+			module.Set("version", hcl.String(version))
+		}
+		module.Newline()
+
+		// Explicit type, or inferred from the fields for older manifests
+		switch blueprint.Kind() {
+		case config.KindWebAPI:
+			g.webAPIInputs(module, name, blueprint)
+		case config.KindStaticSite:
+			g.staticSiteInputs(module, name, blueprint)
+		case config.KindDatabase:
+			g.databaseInputs(module, name, blueprint)
+		}
+	}
 
-resource "aws_apigatewayv2_stage" "%s" {
-  api_id      = aws_apigatewayv2_api.%s.id
-  name        = "$default"
-  auto_deploy = true
+	return file
 }
 
-resource "aws_lambda_permission" "%s" {
-  statement_id  = "AllowAPIGatewayInvoke"
-  action        = "lambda:InvokeFunction"
-  function_name = aws_lambda_function.%s.function_name
-  principal     = "apigateway.amazonaws.com"
-  source_arn    = "${aws_apigatewayv2_api.%s.execution_arn}/*/*"
+// blueprintOrder returns the blueprints with each one after those it uses.
+// Dependency cycles are rejected by config validation; generating one
+// anyway falls back to name order.
+func (g *Generator) blueprintOrder() []string {
+	names, err := g.Env.Order()
+	if err != nil {
+		return g.Env.BlueprintNames()
+	}
+	return names
 }
 
-# WAF for API protection
-resource "aws_wafv2_web_acl" "%s" {
-  name  = "${var.project_name}-${var.environment}-%s-waf"
-  scope = "REGIONAL"
-
-  default_action {
-    allow {}
-  }
-
-  rule {
-    name     = "RateLimitRule"
-    priority = 1
-
-    action {
-      block {}
-    }
-
-    statement {
-      rate_based_statement {
-        limit              = 2000
-        aggregate_key_type = "IP"
-      }
-    }
-
-    visibility_config {
-      cloudwatch_metrics_enabled = true
-      metric_name                = "RateLimitRule"
-      sampled_requests_enabled   = true
-    }
-  }
-
-  visibility_config {
-    cloudwatch_metrics_enabled = true
-    metric_name                = "WAFACL"
-    sampled_requests_enabled   = true
-  }
-}
-`, name, name, name, name, name, name, name, name, name, name, name, name, name, name, name, name, name, name, name, name, name, name, name)
+func (g *Generator) hasKind(kind string) bool {
+	for _, bp := range g.Env.Blueprints {
+		if bp.Kind() == kind {
+			return true
+		}
+	}
+	return false
 }
 
-func (g *Generator) generateStaticSite(name string, bp interface{}) string {
-	if g.Config.Cloud != "aws" {
-		return "# Static site blueprint currently only supports AWS\n"
+func (g *Generator) webAPIInputs(module *hcl.Body, name string, bp config.Blueprint) {
+	module.Set("function_name", hcl.String(g.physicalName("aws_lambda_function", name, name)))
+	module.Set("role_name", hcl.String(g.physicalName("aws_iam_role", name, name+"-lambda-role")))
+	module.Set("api_name", hcl.String(g.physicalName("aws_apigatewayv2_api", name, name)))
+	module.Set("web_acl_name", hcl.String(g.physicalName("aws_wafv2_web_acl", name, name+"-waf")))
+	if bp.Release != nil {
+		module.Set("release_alarms", hcl.Bool(true))
 	}
-
-	denyPublic := ""
-	if g.Config.Policies != nil && g.Config.Policies.DenyPublicS3 {
-		denyPublic = "  # Public access blocked per policy"
+	module.Newline()
+
+	lambda := bp.LambdaSettings()
+	module.Set("runtime", hcl.String(config.LambdaRuntime(bp.LambdaRuntimeName())))
+	module.Set("architecture", hcl.String(lambda.Architecture))
+	module.Set("memory_size", hcl.Number(float64(lambda.Memory)))
+	module.Set("timeout", hcl.Number(float64(lambda.Timeout)))
+	if len(lambda.Layers) > 0 {
+		layers := make([]hcl.Expr, len(lambda.Layers))
+		for i, layer := range lambda.Layers {
+			layers[i] = hcl.String(layer)
+		}
+		module.Set("layers", hcl.List(layers...))
+	}
+	if lambda.ReservedConcurrency != nil {
+		module.Set("reserved_concurrency", hcl.Number(float64(*lambda.ReservedConcurrency)))
+	}
+	if lambda.Tracing {
+		module.Set("tracing", hcl.Bool(true))
+	}
+	if lambda.DeadLetter != "" {
+		module.Set("dead_letter_target_arn", hcl.String(lambda.DeadLetter))
+	}
+	g.usesInputs(module, bp)
+	module.Newline()
+	module.Set("environment", g.lambdaEnvironment(bp))
+}
+
+// lambdaEnvironment returns the function's environment variables. Secret
+// references are resolved through the data sources in secrets.tf.
+func (g *Generator) lambdaEnvironment(bp config.Blueprint) hcl.Expr {
+	values := map[string]hcl.Expr{"ENVIRONMENT": hcl.Ref("var", "environment")}
+	g.usesEnvironment(values, bp)
+	for key, value := range bp.Env {
+		if ref, err := config.ParseSecretRef(value); err == nil {
+			values[key] = secretExpr(ref)
+		} else {
+			values[key] = hcl.String(value)
+		}
 	}
 
-	return fmt.Sprintf(`
-# S3 bucket for static site
-resource "aws_s3_bucket" "%s" {
-  bucket = "${var.project_name}-${var.environment}-%s"
-}
-
-resource "aws_s3_bucket_website_configuration" "%s" {
-  bucket = aws_s3_bucket.%s.id
-
-  index_document {
-    suffix = "index.html"
-  }
-
-  error_document {
-    key = "error.html"
-  }
-}
-
-resource "aws_s3_bucket_public_access_block" "%s" {
-  bucket = aws_s3_bucket.%s.id
-
-  block_public_acls       = true
-  block_public_policy     = true
-  ignore_public_acls      = true
-  restrict_public_buckets = true
-  %s
-}
-
-# CloudFront distribution
-resource "aws_cloudfront_distribution" "%s" {
-  enabled             = true
-  default_root_object = "index.html"
-
-  origin {
-    domain_name = aws_s3_bucket.%s.bucket_regional_domain_name
-    origin_id   = "S3-${aws_s3_bucket.%s.id}"
-
-    s3_origin_config {
-      origin_access_identity = aws_cloudfront_origin_access_identity.%s.cloudfront_access_identity_path
-    }
-  }
-
-  default_cache_behavior {
-    allowed_methods        = ["GET", "HEAD", "OPTIONS"]
-    cached_methods         = ["GET", "HEAD"]
-    target_origin_id       = "S3-${aws_s3_bucket.%s.id}"
-    viewer_protocol_policy = "redirect-to-https"
-
-    forwarded_values {
-      query_string = false
-      cookies {
-        forward = "none"
-      }
-    }
-  }
-
-  restrictions {
-    geo_restriction {
-      restriction_type = "none"
-    }
-  }
-
-  viewer_certificate {
-    cloudfront_default_certificate = true
-  }
-}
-
-resource "aws_cloudfront_origin_access_identity" "%s" {
-  comment = "OAI for ${var.project_name}-${var.environment}-%s"
-}
-
-resource "aws_s3_bucket_policy" "%s" {
-  bucket = aws_s3_bucket.%s.id
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
 
-  policy = jsonencode({
-    Version = "2012-10-17"
-    Statement = [{
-      Sid    = "AllowCloudFrontAccess"
-      Effect = "Allow"
-      Principal = {
-        AWS = aws_cloudfront_origin_access_identity.%s.iam_arn
-      }
-      Action   = "s3:GetObject"
-      Resource = "${aws_s3_bucket.%s.arn}/*"
-    }]
-  })
-}
-`, name, name, name, name, name, name, denyPublic, name, name, name, name, name, name, name, name, name, name, name)
+	variables := hcl.NewObject()
+	for _, key := range keys {
+		variables.Set(key, values[key])
+	}
+	return variables
 }
 
-func (g *Generator) generateDatabase(name string, bp interface{}) string {
-	return fmt.Sprintf(`
-# Database blueprint: %s
-# TODO: Implement database resources based on type
-`, name)
+func (g *Generator) staticSiteInputs(module *hcl.Body, name string, bp config.Blueprint) {
+	bucketName := g.physicalName("aws_s3_bucket", name, name)
+	if g.Config.AccountSuffix() {
+		module.Set("bucket_name", hcl.Template(hcl.String(bucketName+"-"), hcl.Ref("data", "aws_caller_identity", "current", "account_id")))
+	} else {
+		module.Set("bucket_name", hcl.String(bucketName))
+	}
+	if bp.APIOrigin != "" {
+		module.Set("api_domain", hcl.Ref("module", bp.APIOrigin, "api_domain"))
+	}
 }
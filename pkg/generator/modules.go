@@ -0,0 +1,648 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/OplexTech/soloops-cli/pkg/config"
+	"github.com/OplexTech/soloops-cli/pkg/hcl"
+)
+
+// ModuleVersion is the version of the modules written to infra/modules.
+// Copies published to a registry or git repository should be tagged with it.
+const ModuleVersion = "1.0.0"
+
+// protectedSuffix marks module variants whose resources set prevent_destroy,
+// which Terraform does not allow to come from a variable
+const protectedSuffix = "_protected"
+
+// module is a Terraform module instantiated by blueprints
+type module struct {
+	main      *hcl.File
+	variables *hcl.File
+	outputs   *hcl.File
+}
+
+// moduleName returns the module a blueprint instantiates, or "" if its
+// configuration is not supported
+func moduleName(bp config.Blueprint) string {
+	var name string
+	switch bp.Kind() {
+	case config.KindWebAPI:
+		name = "web_api"
+	case config.KindStaticSite:
+		name = "static_site"
+	case config.KindDatabase:
+		switch bp.DBType {
+		case "dynamodb", "aurora_serverless_v2":
+			name = bp.DBType
+		case "postgres", "mysql":
+			name = "rds"
+		default:
+			return ""
+		}
+	default:
+		return ""
+	}
+
+	if bp.Protect {
+		name += protectedSuffix
+	}
+	return name
+}
+
+// moduleSource returns the source and registry version of a module: the
+// local copy by default, or the subdirectory modules/<name> of the source
+// pinned in the manifest
+func (g *Generator) moduleSource(name string) (source, version string) {
+	m := g.Config.Modules
+	switch {
+	case m == nil && g.SharedModules != "":
+		rel, err := filepath.Rel(g.Dir, g.SharedModules)
+		if err != nil {
+			rel = g.SharedModules
+		}
+		rel = filepath.ToSlash(rel)
+		if !strings.HasPrefix(rel, "../") {
+			rel = "./" + rel
+		}
+		return rel + "/" + name, ""
+	case m == nil:
+		return "./modules/" + name, ""
+	case m.IsLocal():
+		return strings.TrimRight(m.Source, "/") + "/modules/" + name, ""
+	case m.IsGit():
+		source = m.Source + "//modules/" + name
+		if m.Version != "" {
+			source += "?ref=" + m.Version
+		}
+		return source, ""
+	default:
+		return m.Source + "//modules/" + name, m.Version
+	}
+}
+
+// blueprintModule returns the module a blueprint instantiates: its own copy
+// when it patches resources, otherwise the one shared by its type
+func blueprintModule(name string, bp config.Blueprint) string {
+	module := moduleName(bp)
+	if module == "" || len(bp.Terraform) == 0 {
+		return module
+	}
+	return module + "-" + name
+}
+
+// localModules returns the modules generated under infra/modules with the
+// patches of the blueprint each belongs to; none when the manifest pins
+// another source or the modules are shared and generated by NewModules'
+// generator. That one collects the modules of every environment, which must
+// then patch a blueprint the same way.
+func (g *Generator) localModules() (map[string]config.Patches, error) {
+	used := map[string]config.Patches{}
+	if g.Config.Modules != nil || g.Config.Cloud != "aws" || g.SharedModules != "" {
+		return used, nil
+	}
+	envs := []*config.Environment{g.Env}
+	if g.Env == nil {
+		envs = nil
+		for i := range g.Config.Environments {
+			envs = append(envs, &g.Config.Environments[i])
+		}
+	}
+
+	owners := map[string]string{}
+	for _, env := range envs {
+		for _, name := range env.BlueprintNames() {
+			bp := env.Blueprints[name]
+			module := blueprintModule(name, bp)
+			if module == "" {
+				continue
+			}
+			if owner, ok := owners[module]; ok && !reflect.DeepEqual(used[module], bp.Terraform) {
+				return nil, fmt.Errorf("blueprint %s has different terraform patches in environments %s and %s, which share %s; generate them with --env instead",
+					name, owner, env.Name, filepath.Join(g.Dir, module))
+			}
+			used[module] = bp.Terraform
+			owners[module] = env.Name
+		}
+	}
+	return used, nil
+}
+
+// moduleBase returns the blueprint type a generated module implements, e.g.
+// web_api for web_api_protected-orders
+func moduleBase(module string) string {
+	return strings.TrimSuffix(strings.SplitN(module, "-", 2)[0], protectedSuffix)
+}
+
+// moduleDir returns the directory a local module is written to, relative to
+// Dir
+func (g *Generator) moduleDir(module string) string {
+	if g.Env == nil {
+		return module
+	}
+	return path.Join("modules", module)
+}
+
+// generateModules writes the modules the environment's blueprints use to
+// infra/modules, unless the manifest pins them to another source
+func (g *Generator) generateModules() error {
+	used, err := g.localModules()
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(used))
+	for name := range used {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		m := buildModule(strings.SplitN(name, "-", 2)[0])
+		if err := m.patch(used[name]); err != nil {
+			return err
+		}
+		dir := filepath.FromSlash(g.moduleDir(name))
+		for file, content := range map[string]*hcl.File{
+			"main.tf":      m.main,
+			"variables.tf": m.variables,
+			"outputs.tf":   m.outputs,
+			"versions.tf":  moduleVersions(name),
+		} {
+			if err := g.writeFile(filepath.Join(dir, file), content); err != nil {
+				return fmt.Errorf("failed to write module %s: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// checkPatches reports terraform patches that name resources or attributes
+// the blueprints' modules do not have
+func (g *Generator) checkPatches() error {
+	if g.Config.Cloud != "aws" {
+		return nil
+	}
+	for _, name := range g.Env.BlueprintNames() {
+		bp := g.Env.Blueprints[name]
+		if len(bp.Terraform) == 0 || moduleName(bp) == "" {
+			continue
+		}
+		if err := buildModule(moduleName(bp)).patch(bp.Terraform); err != nil {
+			return fmt.Errorf("blueprint %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// patch sets the attributes of patches on the module's resources
+func (m *module) patch(patches config.Patches) error {
+	resources := map[string]*hcl.Body{}
+	var addresses []string
+	for _, block := range m.main.Body().Blocks() {
+		if block.Type == "resource" && len(block.Labels) == 2 {
+			address := block.Labels[0] + "." + block.Labels[1]
+			resources[address] = block.Body
+			addresses = append(addresses, address)
+		}
+	}
+
+	for _, address := range patches.Resources() {
+		body, ok := resources[address]
+		if !ok {
+			return fmt.Errorf("terraform: no resource %s (generated: %s)", address, strings.Join(addresses, ", "))
+		}
+
+		attributes := make([]string, 0, len(patches[address]))
+		for attribute := range patches[address] {
+			attributes = append(attributes, attribute)
+		}
+		sort.Strings(attributes)
+
+		added := false
+		for _, attribute := range attributes {
+			for _, block := range body.Blocks() {
+				if block.Type == attribute {
+					return fmt.Errorf("terraform: %s.%s is a block; change it with a file in %s/", address, attribute, OverridesDir)
+				}
+			}
+			value, err := hcl.Value(patches[address][attribute])
+			if err != nil {
+				return fmt.Errorf("terraform: %s.%s: %w", address, attribute, err)
+			}
+			if !added && !body.Has(attribute) {
+				body.Newline()
+				body.Comment("Set by the blueprint's terraform patches")
+				added = true
+			}
+			body.Set(attribute, value)
+		}
+	}
+	return nil
+}
+
+func buildModule(name string) *module {
+	protect := strings.HasSuffix(name, protectedSuffix)
+	m := &module{main: moduleFile(name), variables: moduleFile(name), outputs: moduleFile(name)}
+
+	switch strings.TrimSuffix(name, protectedSuffix) {
+	case "web_api":
+		webAPIModule(m, protect)
+	case "static_site":
+		staticSiteModule(m, protect)
+	case "dynamodb":
+		dynamoDBModule(m, protect)
+	case "aurora_serverless_v2":
+		auroraModule(m, protect)
+	case "rds":
+		rdsModule(m, protect)
+	}
+	return m
+}
+
+func moduleFile(name string) *hcl.File {
+	file := hcl.NewFile()
+	file.Body().Comment(fmt.Sprintf("SoloOps module %s v%s, generated by 'soloops generate'", name, ModuleVersion))
+	return file
+}
+
+func moduleVersions(name string) *hcl.File {
+	file := moduleFile(name)
+	body := file.Body()
+	body.Newline()
+	body.Block("terraform").Block("required_providers").Set("aws", requiredProvider("hashicorp/aws", "~> 5.0"))
+	return file
+}
+
+// variable declares a module input; def may be nil for required inputs
+func variable(body *hcl.Body, name, description string, typ, def hcl.Expr) {
+	body.Newline()
+	v := body.Block("variable", name)
+	v.Set("description", hcl.String(description))
+	v.Set("type", typ)
+	if def != nil {
+		v.Set("default", def)
+	}
+}
+
+var (
+	typeString     = hcl.Ref("string")
+	typeBool       = hcl.Ref("bool")
+	typeNumber     = hcl.Ref("number")
+	typeMapString  = hcl.Call("map", hcl.Ref("string"))
+	typeListString = hcl.Call("list", hcl.Ref("string"))
+)
+
+func webAPIModule(m *module, protect bool) {
+	vars := m.variables.Body()
+	variable(vars, "function_name", "Name of the Lambda function", typeString, nil)
+	variable(vars, "role_name", "Name of the Lambda execution role", typeString, nil)
+	variable(vars, "api_name", "Name of the HTTP API", typeString, nil)
+	variable(vars, "web_acl_name", "Name of the WAF web ACL", typeString, nil)
+	variable(vars, "environment", "Environment variables of the function", typeMapString, hcl.NewObject())
+	variable(vars, "runtime", "Lambda runtime identifier", typeString, hcl.String(config.LambdaRuntime(config.SupportedRuntimes[0])))
+	variable(vars, "architecture", "Instruction set architecture, x86_64 or arm64", typeString, hcl.String(config.ArchX86))
+	variable(vars, "memory_size", "Memory of the function in MB", typeNumber, hcl.Number(config.DefaultLambdaMemory))
+	variable(vars, "timeout", "Timeout of the function in seconds", typeNumber, hcl.Number(config.DefaultLambdaTimeout))
+	variable(vars, "layers", "Layer version ARNs", typeListString, hcl.List())
+	variable(vars, "reserved_concurrency", "Reserved concurrent executions, or -1 for unreserved", typeNumber, hcl.Number(-1))
+	variable(vars, "tracing", "Enable X-Ray active tracing", typeBool, hcl.Bool(false))
+	variable(vars, "dead_letter_target_arn", "SQS queue or SNS topic for failed asynchronous invocations", typeString, hcl.String(""))
+	variable(vars, "release_alarms", "Create the alarms 'soloops deploy' watches during releases", typeBool, hcl.Bool(false))
+
+	body := m.main.Body()
+	fn := resource(body, "Lambda function", "aws_lambda_function", "this")
+	fn.Set("function_name", hcl.Ref("var", "function_name"))
+	fn.Set("role", hcl.Ref("aws_iam_role", "lambda", "arn"))
+	fn.Set("handler", hcl.String("index.handler"))
+	fn.Set("runtime", hcl.Ref("var", "runtime"))
+	fn.Set("architectures", hcl.List(hcl.Ref("var", "architecture")))
+	fn.Set("memory_size", hcl.Ref("var", "memory_size"))
+	fn.Set("timeout", hcl.Ref("var", "timeout"))
+	fn.Set("layers", hcl.Ref("var", "layers"))
+	fn.Set("filename", hcl.String("lambda_placeholder.zip"))
+	fn.Set("publish", hcl.Bool(true))
+	fn.Newline()
+	fn.Set("reserved_concurrent_executions", hcl.Ref("var", "reserved_concurrency"))
+	fn.Newline()
+	fn.Block("environment").Set("variables", hcl.Ref("var", "environment"))
+	fn.Newline()
+	fn.Block("tracing_config").Set("mode", hcl.Conditional(hcl.Ref("var", "tracing"), hcl.String("Active"), hcl.String("PassThrough")))
+	fn.Newline()
+	deadLetter := fn.Block("dynamic", "dead_letter_config")
+	deadLetter.Set("for_each", hcl.Call("compact", hcl.List(hcl.Ref("var", "dead_letter_target_arn"))))
+	deadLetter.Newline()
+	deadLetter.Block("content").Set("target_arn", hcl.Ref("dead_letter_config", "value"))
+	vpcConfig(fn)
+	lambdaLifecycle(fn, protect)
+
+	live := resource(body, "Traffic is served through the live alias, which 'soloops deploy' moves to\neach newly published version", "aws_lambda_alias", "live")
+	live.Set("name", hcl.String("live"))
+	live.Set("function_name", hcl.Ref("aws_lambda_function", "this", "function_name"))
+	live.Set("function_version", hcl.Ref("aws_lambda_function", "this", "version"))
+	live.Newline()
+	live.Block("lifecycle").Set("ignore_changes", hcl.List(hcl.Ref("routing_config")))
+
+	iamRole := resource(body, "", "aws_iam_role", "lambda")
+	iamRole.Set("name", hcl.Ref("var", "role_name"))
+	iamRole.Newline()
+	iamRole.Set("assume_role_policy", policyDocument(hcl.NewObject().
+		Set("Action", hcl.String("sts:AssumeRole")).
+		Set("Effect", hcl.String("Allow")).
+		Set("Principal", hcl.NewObject().
+			Set("Service", hcl.String("lambda.amazonaws.com")))))
+
+	attachment := resource(body, "", "aws_iam_role_policy_attachment", "lambda")
+	attachment.Set("role", hcl.Ref("aws_iam_role", "lambda", "name"))
+	attachment.Set("policy_arn", hcl.String("arn:aws:iam::aws:policy/service-role/AWSLambdaBasicExecutionRole"))
+
+	xray := resource(body, "", "aws_iam_role_policy_attachment", "xray")
+	xray.Set("count", hcl.Conditional(hcl.Ref("var", "tracing"), hcl.Number(1), hcl.Number(0)))
+	xray.Newline()
+	xray.Set("role", hcl.Ref("aws_iam_role", "lambda", "name"))
+	xray.Set("policy_arn", hcl.String("arn:aws:iam::aws:policy/AWSXRayDaemonWriteAccess"))
+
+	dlq := resource(body, "", "aws_iam_role_policy", "dead_letter")
+	dlq.Set("count", hcl.Conditional(hcl.Binary(hcl.Ref("var", "dead_letter_target_arn"), "==", hcl.String("")), hcl.Number(0), hcl.Number(1)))
+	dlq.Newline()
+	dlq.Set("name", hcl.String("dead-letter"))
+	dlq.Set("role", hcl.Ref("aws_iam_role", "lambda", "id"))
+	dlq.Set("policy", policyDocument(hcl.NewObject().
+		Set("Action", hcl.List(hcl.String("sqs:SendMessage"), hcl.String("sns:Publish"))).
+		Set("Effect", hcl.String("Allow")).
+		Set("Resource", hcl.Ref("var", "dead_letter_target_arn"))))
+
+	usesAccess(m)
+
+	api := resource(body, "API Gateway", "aws_apigatewayv2_api", "this")
+	api.Set("name", hcl.Ref("var", "api_name"))
+	api.Set("protocol_type", hcl.String("HTTP"))
+	lifecycle(api, protect)
+
+	integration := resource(body, "", "aws_apigatewayv2_integration", "this")
+	integration.Set("api_id", hcl.Ref("aws_apigatewayv2_api", "this", "id"))
+	integration.Set("integration_type", hcl.String("AWS_PROXY"))
+	integration.Set("integration_uri", hcl.Ref("aws_lambda_alias", "live", "invoke_arn"))
+
+	route := resource(body, "", "aws_apigatewayv2_route", "this")
+	route.Set("api_id", hcl.Ref("aws_apigatewayv2_api", "this", "id"))
+	route.Set("route_key", hcl.String("$default"))
+	route.Set("target", hcl.Template(hcl.String("integrations/"), hcl.Ref("aws_apigatewayv2_integration", "this", "id")))
+
+	stage := resource(body, "", "aws_apigatewayv2_stage", "this")
+	stage.Set("api_id", hcl.Ref("aws_apigatewayv2_api", "this", "id"))
+	stage.Set("name", hcl.String("$default"))
+	stage.Set("auto_deploy", hcl.Bool(true))
+
+	permission := resource(body, "", "aws_lambda_permission", "apigateway")
+	permission.Set("statement_id", hcl.String("AllowAPIGatewayInvoke"))
+	permission.Set("action", hcl.String("lambda:InvokeFunction"))
+	permission.Set("function_name", hcl.Ref("aws_lambda_function", "this", "function_name"))
+	permission.Set("qualifier", hcl.Ref("aws_lambda_alias", "live", "name"))
+	permission.Set("principal", hcl.String("apigateway.amazonaws.com"))
+	permission.Set("source_arn", hcl.Template(hcl.Ref("aws_apigatewayv2_api", "this", "execution_arn"), hcl.String("/*/*")))
+
+	waf := resource(body, "WAF for API protection", "aws_wafv2_web_acl", "this")
+	waf.Set("name", hcl.Ref("var", "web_acl_name"))
+	waf.Set("scope", hcl.String("REGIONAL"))
+	waf.Newline()
+	waf.Block("default_action").Block("allow")
+	waf.Newline()
+	rule := waf.Block("rule")
+	rule.Set("name", hcl.String("RateLimitRule"))
+	rule.Set("priority", hcl.Number(1))
+	rule.Newline()
+	rule.Block("action").Block("block")
+	rule.Newline()
+	rateLimit := rule.Block("statement").Block("rate_based_statement")
+	rateLimit.Set("limit", hcl.Number(2000))
+	rateLimit.Set("aggregate_key_type", hcl.String("IP"))
+	rule.Newline()
+	visibilityConfig(rule.Block("visibility_config"), "RateLimitRule")
+	waf.Newline()
+	visibilityConfig(waf.Block("visibility_config"), "WAFACL")
+
+	releaseAlarms(body)
+
+	outputs := m.outputs.Body()
+	output(outputs, "api_url", "API Gateway endpoint URL", hcl.Ref("aws_apigatewayv2_stage", "this", "invoke_url"))
+	output(outputs, "api_domain", "API Gateway domain, for static sites serving the API as an origin",
+		hcl.Call("replace", hcl.Ref("aws_apigatewayv2_api", "this", "api_endpoint"), hcl.String("https://"), hcl.String("")))
+	output(outputs, "lambda_arn", "Lambda function ARN", hcl.Ref("aws_lambda_function", "this", "arn"))
+	output(outputs, "live_alias_arn", "ARN of the live alias, which callers invoke", hcl.Ref("aws_lambda_alias", "live", "arn"))
+	output(outputs, "release_alarm_names", "Alarms watched during releases",
+		hcl.Call("concat",
+			hcl.Ref("aws_cloudwatch_metric_alarm", "release_errors[*]", "alarm_name"),
+			hcl.Ref("aws_cloudwatch_metric_alarm", "release_5xx[*]", "alarm_name")))
+}
+
+func visibilityConfig(body *hcl.Body, metric string) {
+	body.Set("cloudwatch_metrics_enabled", hcl.Bool(true))
+	body.Set("metric_name", hcl.String(metric))
+	body.Set("sampled_requests_enabled", hcl.Bool(true))
+}
+
+// policyDocument returns jsonencode() of an IAM policy with one statement
+func policyDocument(statement *hcl.Object) hcl.Expr {
+	return hcl.Call("jsonencode", hcl.NewObject().
+		Set("Version", hcl.String("2012-10-17")).
+		Set("Statement", hcl.List(statement)))
+}
+
+// releaseAlarms adds the alarms 'soloops deploy' watches while it shifts
+// traffic to a new version, created when var.release_alarms is set
+func releaseAlarms(body *hcl.Body) {
+	errors := resource(body, "Alarms watched during releases; any of them firing rolls the release back",
+		"aws_cloudwatch_metric_alarm", "release_errors")
+	releaseAlarm(errors, "-release-errors", "Lambda errors on the live alias of ", "AWS/Lambda", "Errors")
+	errors.Set("dimensions", hcl.NewObject().
+		Set("FunctionName", hcl.Ref("aws_lambda_function", "this", "function_name")).
+		Set("Resource", hcl.Template(
+			hcl.Ref("aws_lambda_function", "this", "function_name"), hcl.String(":"), hcl.Ref("aws_lambda_alias", "live", "name"))))
+
+	serverErrors := resource(body, "", "aws_cloudwatch_metric_alarm", "release_5xx")
+	releaseAlarm(serverErrors, "-release-5xx", "API Gateway 5xx responses of ", "AWS/ApiGateway", "5xx")
+	serverErrors.Set("dimensions", hcl.NewObject().
+		Set("ApiId", hcl.Ref("aws_apigatewayv2_api", "this", "id")).
+		Set("Stage", hcl.Ref("aws_apigatewayv2_stage", "this", "name")))
+}
+
+// releaseAlarm sets the attributes shared by release alarms, which fire on
+// any occurrence of the metric within a minute
+func releaseAlarm(alarm *hcl.Body, suffix, description, namespace, metric string) {
+	alarm.Set("count", hcl.Conditional(hcl.Ref("var", "release_alarms"), hcl.Number(1), hcl.Number(0)))
+	alarm.Newline()
+	alarm.Set("alarm_name", hcl.Template(hcl.Ref("var", "function_name"), hcl.String(suffix)))
+	alarm.Set("alarm_description", hcl.Template(hcl.String(description), hcl.Ref("var", "function_name")))
+	alarm.Set("namespace", hcl.String(namespace))
+	alarm.Set("metric_name", hcl.String(metric))
+	alarm.Set("statistic", hcl.String("Sum"))
+	alarm.Set("period", hcl.Number(60))
+	alarm.Set("evaluation_periods", hcl.Number(1))
+	alarm.Set("threshold", hcl.Number(0))
+	alarm.Set("comparison_operator", hcl.String("GreaterThanThreshold"))
+	alarm.Set("treat_missing_data", hcl.String("notBreaching"))
+	alarm.Newline()
+}
+
+// CloudFront managed policies for the API origin: no caching, and every
+// viewer header except Host, which API Gateway needs to match its own domain
+const (
+	cachingDisabledPolicy     = "4135ea2d-6df8-44a3-9df3-4b5a84be39ad"
+	allViewerExceptHostPolicy = "b689b0a8-53d0-40ab-baf2-68738e2966ac"
+	apiOriginID               = "api"
+	apiOriginPathPattern      = "/api/*"
+)
+
+func staticSiteModule(m *module, protect bool) {
+	variable(m.variables.Body(), "bucket_name", "Name of the S3 bucket serving the site", typeString, nil)
+	variable(m.variables.Body(), "api_domain", "Domain of the web API served under /api/*, from api_origin", typeString, hcl.String(""))
+
+	body := m.main.Body()
+	bucketID := hcl.Ref("aws_s3_bucket", "this", "id")
+	originID := hcl.Template(hcl.String("S3-"), bucketID)
+
+	bucket := resource(body, "S3 bucket for static site", "aws_s3_bucket", "this")
+	bucket.Set("bucket", hcl.Ref("var", "bucket_name"))
+	lifecycle(bucket, protect)
+
+	website := resource(body, "", "aws_s3_bucket_website_configuration", "this")
+	website.Set("bucket", bucketID)
+	website.Newline()
+	website.Block("index_document").Set("suffix", hcl.String("index.html"))
+	website.Newline()
+	website.Block("error_document").Set("key", hcl.String("error.html"))
+
+	block := resource(body, "Public access is blocked; CloudFront reads through the origin access identity", "aws_s3_bucket_public_access_block", "this")
+	block.Set("bucket", bucketID)
+	block.Newline()
+	block.Set("block_public_acls", hcl.Bool(true))
+	block.Set("block_public_policy", hcl.Bool(true))
+	block.Set("ignore_public_acls", hcl.Bool(true))
+	block.Set("restrict_public_buckets", hcl.Bool(true))
+
+	cdn := resource(body, "CloudFront distribution", "aws_cloudfront_distribution", "this")
+	cdn.Set("enabled", hcl.Bool(true))
+	cdn.Set("default_root_object", hcl.String("index.html"))
+	cdn.Newline()
+	origin := cdn.Block("origin")
+	origin.Set("domain_name", hcl.Ref("aws_s3_bucket", "this", "bucket_regional_domain_name"))
+	origin.Set("origin_id", originID)
+	origin.Newline()
+	origin.Block("s3_origin_config").Set("origin_access_identity",
+		hcl.Ref("aws_cloudfront_origin_access_identity", "this", "cloudfront_access_identity_path"))
+	cdn.Newline()
+	apiOrigin(cdn)
+	cdn.Newline()
+	behavior := cdn.Block("default_cache_behavior")
+	behavior.Set("allowed_methods", hcl.List(hcl.String("GET"), hcl.String("HEAD"), hcl.String("OPTIONS")))
+	behavior.Set("cached_methods", hcl.List(hcl.String("GET"), hcl.String("HEAD")))
+	behavior.Set("target_origin_id", originID)
+	behavior.Set("viewer_protocol_policy", hcl.String("redirect-to-https"))
+	behavior.Newline()
+	forwarded := behavior.Block("forwarded_values")
+	forwarded.Set("query_string", hcl.Bool(false))
+	forwarded.Block("cookies").Set("forward", hcl.String("none"))
+	cdn.Newline()
+	apiBehavior(cdn)
+	cdn.Newline()
+	cdn.Block("restrictions").Block("geo_restriction").Set("restriction_type", hcl.String("none"))
+	cdn.Newline()
+	cdn.Block("viewer_certificate").Set("cloudfront_default_certificate", hcl.Bool(true))
+
+	oai := resource(body, "", "aws_cloudfront_origin_access_identity", "this")
+	oai.Set("comment", hcl.Template(hcl.String("OAI for "), hcl.Ref("var", "bucket_name")))
+
+	policy := resource(body, "", "aws_s3_bucket_policy", "this")
+	policy.Set("bucket", bucketID)
+	policy.Newline()
+	policy.Set("policy", policyDocument(hcl.NewObject().
+		Set("Sid", hcl.String("AllowCloudFrontAccess")).
+		Set("Effect", hcl.String("Allow")).
+		Set("Principal", hcl.NewObject().
+			Set("AWS", hcl.Ref("aws_cloudfront_origin_access_identity", "this", "iam_arn"))).
+		Set("Action", hcl.String("s3:GetObject")).
+		Set("Resource", hcl.Template(hcl.Ref("aws_s3_bucket", "this", "arn"), hcl.String("/*")))))
+
+	outputs := m.outputs.Body()
+	output(outputs, "bucket_name", "S3 bucket name", bucketID)
+	output(outputs, "cloudfront_url", "CloudFront distribution URL", hcl.Ref("aws_cloudfront_distribution", "this", "domain_name"))
+	output(outputs, "cloudfront_distribution_id", "CloudFront distribution ID", hcl.Ref("aws_cloudfront_distribution", "this", "id"))
+	output(outputs, "bucket_arn", "S3 bucket ARN", hcl.Ref("aws_s3_bucket", "this", "arn"))
+}
+
+// apiOrigin adds the web API of api_origin as an origin of the distribution
+func apiOrigin(cdn *hcl.Body) {
+	origin := cdn.Block("dynamic", "origin")
+	origin.Set("for_each", hcl.Call("compact", hcl.List(hcl.Ref("var", "api_domain"))))
+	origin.Newline()
+	content := origin.Block("content")
+	content.Set("domain_name", hcl.Ref("origin", "value"))
+	content.Set("origin_id", hcl.String(apiOriginID))
+	content.Newline()
+	custom := content.Block("custom_origin_config")
+	custom.Set("http_port", hcl.Number(80))
+	custom.Set("https_port", hcl.Number(443))
+	custom.Set("origin_protocol_policy", hcl.String("https-only"))
+	custom.Set("origin_ssl_protocols", hcl.List(hcl.String("TLSv1.2")))
+}
+
+// apiBehavior routes /api/* to the api_origin origin uncached, with every
+// method allowed
+func apiBehavior(cdn *hcl.Body) {
+	behavior := cdn.Block("dynamic", "ordered_cache_behavior")
+	behavior.Set("for_each", hcl.Call("compact", hcl.List(hcl.Ref("var", "api_domain"))))
+	behavior.Newline()
+	content := behavior.Block("content")
+	content.Set("path_pattern", hcl.String(apiOriginPathPattern))
+	methods := []string{"DELETE", "GET", "HEAD", "OPTIONS", "PATCH", "POST", "PUT"}
+	list := make([]hcl.Expr, len(methods))
+	for i, method := range methods {
+		list[i] = hcl.String(method)
+	}
+	content.Set("allowed_methods", hcl.List(list...))
+	content.Set("cached_methods", hcl.List(hcl.String("GET"), hcl.String("HEAD")))
+	content.Set("target_origin_id", hcl.String(apiOriginID))
+	content.Set("viewer_protocol_policy", hcl.String("https-only"))
+	content.Set("cache_policy_id", hcl.String(cachingDisabledPolicy))
+	content.Set("origin_request_policy_id", hcl.String(allViewerExceptHostPolicy))
+}
+
+// lifecycle adds a lifecycle block to protected resources, which makes
+// Terraform refuse any plan that destroys them
+func lifecycle(resource *hcl.Body, protect bool) {
+	if !protect {
+		return
+	}
+	resource.Newline()
+	resource.Block("lifecycle").Set("prevent_destroy", hcl.Bool(true))
+}
+
+// lambdaLifecycle keeps Terraform from reverting code shipped with
+// 'soloops deploy', on top of the protection from lifecycle
+func lambdaLifecycle(fn *hcl.Body, protect bool) {
+	fn.Newline()
+	block := fn.Block("lifecycle")
+	if protect {
+		block.Set("prevent_destroy", hcl.Bool(true))
+	}
+	block.Set("ignore_changes", hcl.List(hcl.Ref("filename"), hcl.Ref("source_code_hash")))
+}
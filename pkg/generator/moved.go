@@ -0,0 +1,91 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"github.com/OplexTech/soloops-cli/pkg/config"
+	"github.com/OplexTech/soloops-cli/pkg/hcl"
+)
+
+// move is a resource that moved from the flat main.tf of earlier SoloOps
+// versions into a blueprint module
+type move struct {
+	resourceType string
+	from, to     string // labels before and inside the module
+}
+
+// flatResources lists the resources earlier versions generated for a
+// blueprint at the top level of main.tf
+func flatResources(name string, bp config.Blueprint) []move {
+	this := func(types ...string) []move {
+		moves := make([]move, len(types))
+		for i, typ := range types {
+			moves[i] = move{resourceType: typ, from: name, to: "this"}
+		}
+		return moves
+	}
+
+	switch bp.Kind() {
+	case config.KindWebAPI:
+		moves := append(this("aws_lambda_function"),
+			move{"aws_lambda_alias", name + "_live", "live"},
+			move{"aws_iam_role", name + "_lambda_role", "lambda"},
+			move{"aws_iam_role_policy_attachment", name + "_lambda_policy", "lambda"},
+			move{"aws_lambda_permission", name, "apigateway"})
+		moves = append(moves, this("aws_apigatewayv2_api", "aws_apigatewayv2_integration",
+			"aws_apigatewayv2_route", "aws_apigatewayv2_stage", "aws_wafv2_web_acl")...)
+		if bp.Release != nil {
+			moves = append(moves,
+				move{"aws_cloudwatch_metric_alarm", name + "_release_errors", "release_errors[0]"},
+				move{"aws_cloudwatch_metric_alarm", name + "_release_5xx", "release_5xx[0]"})
+		}
+		return moves
+	case config.KindStaticSite:
+		return this("aws_s3_bucket", "aws_s3_bucket_website_configuration", "aws_s3_bucket_public_access_block",
+			"aws_cloudfront_distribution", "aws_cloudfront_origin_access_identity", "aws_s3_bucket_policy")
+	case config.KindDatabase:
+		switch bp.DBType {
+		case "dynamodb":
+			return this("aws_dynamodb_table")
+		case "aurora_serverless_v2":
+			return this("aws_rds_cluster", "aws_rds_cluster_instance")
+		case "postgres", "mysql":
+			return this("aws_db_instance")
+		}
+	}
+	return nil
+}
+
+// generateMoved writes moved {} blocks so state created by earlier versions,
+// which generated every resource at the top level of main.tf, follows the
+// resources into their blueprint modules instead of being replaced
+func (g *Generator) generateMoved() error {
+	file := hcl.NewFile()
+	body := file.Body()
+	body.Comment("Resources generated before blueprints became modules")
+
+	if g.Config.Cloud == "aws" {
+		for _, name := range g.Env.BlueprintNames() {
+			for _, m := range flatResources(name, g.Env.Blueprints[name]) {
+				body.Newline()
+				moved := body.Block("moved")
+				moved.Set("from", hcl.Ref(m.resourceType, m.from))
+				moved.Set("to", hcl.Ref("module", name, m.resourceType, m.to))
+			}
+		}
+	}
+
+	return g.writeFile("moved.tf", file)
+}
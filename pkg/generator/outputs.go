@@ -15,58 +15,57 @@
 package generator
 
 import (
-	"fmt"
-	"strings"
+	"github.com/OplexTech/soloops-cli/pkg/config"
+	"github.com/OplexTech/soloops-cli/pkg/hcl"
 )
 
 func (g *Generator) generateOutputs() error {
-	var outputs strings.Builder
+	file := hcl.NewFile()
+	body := file.Body()
 
-	outputs.WriteString("# Terraform outputs\n\n")
+	body.Comment("Terraform outputs")
 
-	// Generate outputs for each blueprint
-	for name, blueprint := range g.Env.Blueprints {
-		if blueprint.Runtime != "" || blueprint.Ingress != "" {
-			// Web API outputs
-			outputs.WriteString(fmt.Sprintf(`output "%s_api_url" {
-  description = "API Gateway endpoint URL for %s"
-  value       = try(aws_apigatewayv2_stage.%s.invoke_url, "N/A")
-}
-
-output "%s_lambda_arn" {
-  description = "Lambda function ARN for %s"
-  value       = try(aws_lambda_function.%s.arn, "N/A")
-}
-
-`, name, name, name, name, name, name))
+	// Generate outputs for each blueprint; other clouds have no blueprint
+	// modules yet
+	for _, name := range g.blueprintOrder() {
+		if g.Config.Cloud != "aws" {
+			break
 		}
+		blueprint := g.Env.Blueprints[name]
+		switch blueprint.Kind() {
+		case config.KindWebAPI:
+			output(body, name+"_api_url", "API Gateway endpoint URL for "+name, orNA(name, "api_url"))
+			output(body, name+"_lambda_arn", "Lambda function ARN for "+name, orNA(name, "lambda_arn"))
+			if blueprint.Release != nil {
+				output(body, name+"_release_alarms", "Alarms watched while releasing "+name+", comma-separated",
+					hcl.Call("join", hcl.String(","), hcl.Ref("module", name, "release_alarm_names")))
+			}
 
-		if blueprint.Domain != "" {
-			// Static site outputs
-			outputs.WriteString(fmt.Sprintf(`output "%s_bucket_name" {
-  description = "S3 bucket name for %s"
-  value       = try(aws_s3_bucket.%s.id, "N/A")
-}
-
-output "%s_cloudfront_url" {
-  description = "CloudFront distribution URL for %s"
-  value       = try(aws_cloudfront_distribution.%s.domain_name, "N/A")
-}
+		case config.KindStaticSite:
+			output(body, name+"_bucket_name", "S3 bucket name for "+name, orNA(name, "bucket_name"))
+			output(body, name+"_cloudfront_url", "CloudFront distribution URL for "+name, orNA(name, "cloudfront_url"))
+			output(body, name+"_cloudfront_distribution_id", "CloudFront distribution ID for "+name, orNA(name, "cloudfront_distribution_id"))
 
-`, name, name, name, name, name, name))
+		case config.KindDatabase:
+			g.databaseOutputs(body, name, blueprint)
 		}
 	}
 
-	outputs.WriteString(`output "environment" {
-  description = "Environment name"
-  value       = var.environment
+	output(body, "environment", "Environment name", hcl.Ref("var", "environment"))
+	output(body, "region", "Deployment region", hcl.Ref("var", "region"))
+
+	return g.writeFile("outputs.tf", file)
 }
 
-output "region" {
-  description = "Deployment region"
-  value       = var.region
+func output(body *hcl.Body, name, description string, value hcl.Expr) {
+	body.Newline()
+	out := body.Block("output", name)
+	out.Set("description", hcl.String(description))
+	out.Set("value", value)
 }
-`)
 
-	return g.writeFile("outputs.tf", outputs.String())
+// orNA reads a blueprint module's output, falling back to "N/A" when it is
+// not known yet
+func orNA(blueprint, output string) hcl.Expr {
+	return hcl.Call("try", hcl.Ref("module", blueprint, output), hcl.String("N/A"))
 }
@@ -0,0 +1,165 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/OplexTech/soloops-cli/pkg/config"
+)
+
+// OverridesDir is the default directory of hand-written Terraform copied into
+// the generated directory on every generate. Files ending in _override.tf are
+// merged into the generated blocks by Terraform; any other .tf file adds
+// resources. Subdirectories mirror the generated directory, except that
+// overrides/modules/<type>/ applies to every module generated for that
+// blueprint type: web_api, web_api_protected and the web_api-<name> copies
+// of patched blueprints. overrides/modules/<module>/ targets one module and
+// wins over the type's files.
+const OverridesDir = "overrides"
+
+// copyOverrides adds the .tf files under Overrides to the output, refusing
+// to replace a generated file or to target a module that is not generated
+func (g *Generator) copyOverrides() error {
+	files, err := g.overrideFiles(g.Overrides, "")
+	if err != nil {
+		return err
+	}
+	rels := make([]string, 0, len(files))
+	for rel := range files {
+		rels = append(rels, rel)
+	}
+	sort.Strings(rels)
+
+	modules, err := g.localModules()
+	if err != nil {
+		return err
+	}
+	generated := make(map[string]bool, len(g.files))
+	for target := range g.files {
+		generated[target] = true
+	}
+
+	// Files for one module are copied first, so they win over the files for
+	// every module of a type
+	specific := map[string]bool{}
+	for _, typeWidePass := range []bool{false, true} {
+		for _, rel := range rels {
+			// Shared modules get their overrides from NewModules' generator,
+			// which only applies those
+			if isModule := strings.HasPrefix(rel, "modules/"); g.SharedModules != "" && isModule || g.Env == nil && !isModule {
+				continue
+			}
+			targets, typeWide, err := overrideTargets(rel, modules)
+			if err != nil {
+				return fmt.Errorf("%s: %w", filepath.Join(g.Overrides, filepath.FromSlash(rel)), err)
+			}
+			if typeWide != typeWidePass {
+				continue
+			}
+			for _, target := range targets {
+				if g.Env == nil {
+					target = strings.TrimPrefix(target, "modules/")
+				}
+				path := filepath.Join(g.Dir, filepath.FromSlash(target))
+				if generated[path] {
+					return fmt.Errorf("%s would replace the generated %s; name it %s to override its blocks",
+						filepath.Join(g.Overrides, filepath.FromSlash(rel)), path,
+						strings.TrimSuffix(filepath.Base(path), ".tf")+"_override.tf")
+				}
+				if typeWide && specific[path] {
+					continue
+				}
+				specific[path] = !typeWide
+				g.files[path] = files[rel]
+			}
+		}
+	}
+	return nil
+}
+
+// overrideTargets maps an override's path onto the generated paths it
+// applies to, and reports whether it applies to every module of a type
+func overrideTargets(rel string, modules map[string]config.Patches) ([]string, bool, error) {
+	parts := strings.SplitN(rel, "/", 3)
+	if len(parts) < 3 || parts[0] != "modules" {
+		return []string{rel}, false, nil
+	}
+	dir, file := parts[1], parts[2]
+	if _, ok := modules[dir]; ok && moduleBase(dir) != dir {
+		return []string{path.Join("modules", dir, file)}, false, nil
+	}
+
+	var targets []string
+	for module := range modules {
+		if moduleBase(module) == dir {
+			targets = append(targets, path.Join("modules", module, file))
+		}
+	}
+	if len(targets) == 0 {
+		names := make([]string, 0, len(modules))
+		for module := range modules {
+			names = append(names, module)
+		}
+		sort.Strings(names)
+		if len(names) == 0 {
+			return nil, false, fmt.Errorf("no modules are generated under modules/")
+		}
+		return nil, false, fmt.Errorf("no generated module matches modules/%s (generated: %s)", dir, strings.Join(names, ", "))
+	}
+	sort.Strings(targets)
+	return targets, true, nil
+}
+
+// overrideFiles reads the .tf files under dir through the generator's
+// filesystem, keyed by slash-separated path relative to dir
+func (g *Generator) overrideFiles(dir, rel string) (map[string][]byte, error) {
+	entries, err := g.FS.ReadDir(filepath.Join(dir, filepath.FromSlash(rel)))
+	if os.IsNotExist(err) && rel == "" {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read overrides: %w", err)
+	}
+
+	files := map[string][]byte{}
+	for _, entry := range entries {
+		name := path.Join(rel, entry.Name())
+		if entry.IsDir() {
+			sub, err := g.overrideFiles(dir, name)
+			if err != nil {
+				return nil, err
+			}
+			for k, v := range sub {
+				files[k] = v
+			}
+			continue
+		}
+		if !strings.HasSuffix(name, ".tf") {
+			continue
+		}
+		data, err := g.FS.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read override: %w", err)
+		}
+		files[name] = data
+	}
+	return files, nil
+}
@@ -14,87 +14,66 @@
 
 package generator
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/OplexTech/soloops-cli/pkg/hcl"
+)
 
 func (g *Generator) generateProvider() error {
-	var content string
+	file := hcl.NewFile()
+	body := file.Body()
+
+	terraform := body.Block("terraform")
+	terraform.Set("required_version", hcl.String(">= 1.5"))
+	terraform.Newline()
+	providers := terraform.Block("required_providers")
+	body.Newline()
 
 	switch g.Config.Cloud {
 	case "aws":
-		content = fmt.Sprintf(`terraform {
-  required_version = ">= 1.5"
-
-  required_providers {
-    aws = {
-      source  = "hashicorp/aws"
-      version = "~> 5.0"
-    }
-  }
-}
+		providers.Set("aws", requiredProvider("hashicorp/aws", "~> 5.0"))
 
-provider "aws" {
-  region = "%s"
-
-  default_tags {
-    tags = {
-      Project     = "%s"
-      Environment = "%s"
-      ManagedBy   = "SoloOps"
-    }
-  }
-}
-`, g.Env.Region, g.Config.Project, g.Env.Name)
+		provider := body.Block("provider", "aws")
+		provider.Set("region", hcl.String(g.Env.Region))
+		provider.Newline()
+		provider.Block("default_tags").Set("tags", hcl.NewObject().
+			Set("Project", hcl.String(g.Config.Project)).
+			Set("Environment", hcl.String(g.Env.Name)).
+			Set("ManagedBy", hcl.String("SoloOps")))
 
 	case "gcp":
-		content = fmt.Sprintf(`terraform {
-  required_version = ">= 1.5"
-
-  required_providers {
-    google = {
-      source  = "hashicorp/google"
-      version = "~> 5.0"
-    }
-  }
-}
+		providers.Set("google", requiredProvider("hashicorp/google", "~> 5.0"))
 
-provider "google" {
-  project = "%s"
-  region  = "%s"
-
-  default_labels = {
-    project     = "%s"
-    environment = "%s"
-    managed_by  = "soloops"
-  }
-}
-`, g.Config.Project, g.Env.Region, g.Config.Project, g.Env.Name)
+		provider := body.Block("provider", "google")
+		provider.Set("project", hcl.String(g.Config.Project))
+		provider.Set("region", hcl.String(g.Env.Region))
+		provider.Newline()
+		provider.Set("default_labels", hcl.NewObject().
+			Set("project", hcl.String(g.Config.Project)).
+			Set("environment", hcl.String(g.Env.Name)).
+			Set("managed_by", hcl.String("soloops")))
 
 	case "azure":
-		content = fmt.Sprintf(`terraform {
-  required_version = ">= 1.5"
-
-  required_providers {
-    azurerm = {
-      source  = "hashicorp/azurerm"
-      version = "~> 3.0"
-    }
-  }
-}
+		providers.Set("azurerm", requiredProvider("hashicorp/azurerm", "~> 3.0"))
 
-provider "azurerm" {
-  features {}
-
-  tags = {
-    Project     = "%s"
-    Environment = "%s"
-    ManagedBy   = "SoloOps"
-  }
-}
-`, g.Config.Project, g.Env.Name)
+		provider := body.Block("provider", "azurerm")
+		provider.Block("features")
+		provider.Newline()
+		provider.Set("tags", hcl.NewObject().
+			Set("Project", hcl.String(g.Config.Project)).
+			Set("Environment", hcl.String(g.Env.Name)).
+			Set("ManagedBy", hcl.String("SoloOps")))
 
 	default:
 		return fmt.Errorf("unsupported cloud provider: %s", g.Config.Cloud)
 	}
 
-	return g.writeFile("provider.tf", content)
+	return g.writeFile("provider.tf", file)
+}
+
+func requiredProvider(source, version string) hcl.Expr {
+	return hcl.NewObject().
+		Set("source", hcl.String(source)).
+		Set("version", hcl.String(version))
 }
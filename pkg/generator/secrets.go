@@ -0,0 +1,96 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/OplexTech/soloops-cli/pkg/config"
+	"github.com/OplexTech/soloops-cli/pkg/hcl"
+)
+
+// secretRefs returns the distinct secret references used by the environment,
+// sorted for stable output
+func (g *Generator) secretRefs() []config.SecretRef {
+	seen := map[string]config.SecretRef{}
+	for _, bp := range g.Env.Blueprints {
+		for _, value := range bp.Env {
+			if !config.IsSecretRef(value) {
+				continue
+			}
+			ref, err := config.ParseSecretRef(value)
+			if err != nil {
+				continue // rejected by Validate
+			}
+			seen[ref.String()] = ref
+		}
+	}
+
+	refs := make([]config.SecretRef, 0, len(seen))
+	for _, ref := range seen {
+		refs = append(refs, ref)
+	}
+	sort.Slice(refs, func(i, j int) bool { return refs[i].String() < refs[j].String() })
+	return refs
+}
+
+// secretExpr returns the Terraform expression that yields a secret's value
+func secretExpr(ref config.SecretRef) hcl.Expr {
+	switch ref.Provider {
+	case config.SecretProviderSSM:
+		return hcl.Ref("data", "aws_ssm_parameter", ref.Identifier(), "value")
+	case config.SecretProviderSecretsManager:
+		return hcl.Ref("data", "aws_secretsmanager_secret_version", ref.Identifier(), "secret_string")
+	default:
+		return hcl.Ref("var", ref.TerraformVariable())
+	}
+}
+
+// generateSecrets declares a data source (or sensitive variable) per secret
+// reference so values are resolved by Terraform and never written to disk
+func (g *Generator) generateSecrets() error {
+	refs := g.secretRefs()
+
+	file := hcl.NewFile()
+	body := file.Body()
+	body.Comment("Secret references")
+	if len(refs) == 0 {
+		body.Comment("No secret:// references in this environment")
+		return g.writeFile("secrets.tf", file)
+	}
+
+	for _, ref := range refs {
+		body.Newline()
+		switch ref.Provider {
+		case config.SecretProviderSSM:
+			data := body.Block("data", "aws_ssm_parameter", ref.Identifier())
+			data.Set("name", hcl.String(ref.Path))
+			data.Set("with_decryption", hcl.Bool(true))
+
+		case config.SecretProviderSecretsManager:
+			data := body.Block("data", "aws_secretsmanager_secret_version", ref.Identifier())
+			data.Set("secret_id", hcl.String(ref.Path))
+
+		case config.SecretProviderEnv:
+			body.Comment(fmt.Sprintf("Supplied by SoloOps from $%s as TF_VAR_%s", ref.Path, ref.TerraformVariable()))
+			variable := body.Block("variable", ref.TerraformVariable())
+			variable.Set("type", hcl.Ref("string"))
+			variable.Set("sensitive", hcl.Bool(true))
+		}
+	}
+
+	return g.writeFile("secrets.tf", file)
+}
@@ -0,0 +1,185 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"github.com/OplexTech/soloops-cli/pkg/config"
+	"github.com/OplexTech/soloops-cli/pkg/hcl"
+)
+
+// Actions granted to a function on the blueprints it uses
+var (
+	dynamoDBActions = []string{
+		"dynamodb:BatchGetItem", "dynamodb:BatchWriteItem", "dynamodb:ConditionCheckItem", "dynamodb:DeleteItem",
+		"dynamodb:GetItem", "dynamodb:PutItem", "dynamodb:Query", "dynamodb:Scan", "dynamodb:UpdateItem",
+	}
+	objectActions = []string{"s3:DeleteObject", "s3:GetObject", "s3:PutObject"}
+)
+
+// usesInputs passes a web_api the IAM statements and database network access
+// for the blueprints it uses, in the order they are listed
+func (g *Generator) usesInputs(module *hcl.Body, bp config.Blueprint) {
+	var statements, databases []hcl.Expr
+	for _, name := range bp.Uses {
+		used := g.Env.Blueprints[name]
+		ref := func(output string) hcl.Expr { return hcl.Ref("module", name, output) }
+
+		switch {
+		case used.Kind() == config.KindWebAPI:
+			statements = append(statements, statement([]string{"lambda:InvokeFunction"}, ref("live_alias_arn")))
+		case used.Kind() == config.KindStaticSite:
+			bucket := ref("bucket_arn")
+			statements = append(statements,
+				statement(objectActions, hcl.Template(bucket, hcl.String("/*"))),
+				statement([]string{"s3:ListBucket"}, bucket))
+		case used.Kind() == config.KindDatabase && used.DBType == "dynamodb":
+			table := ref("table_arn")
+			statements = append(statements, statement(dynamoDBActions, table, hcl.Template(table, hcl.String("/index/*"))))
+		case used.Relational():
+			statements = append(statements, statement([]string{"secretsmanager:GetSecretValue"}, ref("secret_arn")))
+			databases = append(databases, hcl.NewObject().
+				Set("security_group_id", ref("security_group_id")).
+				Set("port", ref("port")))
+		}
+	}
+
+	if len(statements) > 0 {
+		module.Newline()
+		module.Set("access_statements", hcl.List(statements...))
+	}
+	if len(databases) > 0 {
+		module.Set("database_access", hcl.List(databases...))
+	}
+}
+
+// usesEnvironment adds the identifiers of the blueprints a web_api uses to
+// its environment variables
+func (g *Generator) usesEnvironment(values map[string]hcl.Expr, bp config.Blueprint) {
+	for _, name := range bp.Uses {
+		for key, out := range g.Env.Blueprints[name].UsedEnv(name) {
+			values[key] = hcl.Ref("module", name, out)
+		}
+	}
+}
+
+func statement(actions []string, resources ...hcl.Expr) hcl.Expr {
+	list := make([]hcl.Expr, len(actions))
+	for i, action := range actions {
+		list[i] = hcl.String(action)
+	}
+	return hcl.NewObject().
+		Set("Effect", hcl.String("Allow")).
+		Set("Action", hcl.List(list...)).
+		Set("Resource", hcl.List(resources...))
+}
+
+// usesAccess adds the resources that grant a function access to the
+// blueprints it uses: an IAM policy from var.access_statements and, for
+// databases, a security group in the default VPC allowed into theirs
+func usesAccess(m *module) {
+	vars := m.variables.Body()
+	variable(vars, "access_statements", "IAM policy statements for the blueprints the function uses",
+		hcl.Call("list", hcl.Call("object", hcl.NewObject().
+			Set("Effect", typeString).
+			Set("Action", typeListString).
+			Set("Resource", typeListString))), hcl.List())
+	variable(vars, "database_access", "Security groups and ports of the databases the function connects to",
+		hcl.Call("list", hcl.Call("object", hcl.NewObject().
+			Set("security_group_id", typeString).
+			Set("port", typeNumber))), hcl.List())
+
+	body := m.main.Body()
+	access := resource(body, "Access to the blueprints listed in uses", "aws_iam_role_policy", "access")
+	access.Set("count", hcl.Conditional(hcl.Binary(hcl.Call("length", hcl.Ref("var", "access_statements")), ">", hcl.Number(0)), hcl.Number(1), hcl.Number(0)))
+	access.Newline()
+	access.Set("name", hcl.String("access"))
+	access.Set("role", hcl.Ref("aws_iam_role", "lambda", "id"))
+	access.Set("policy", hcl.Call("jsonencode", hcl.NewObject().
+		Set("Version", hcl.String("2012-10-17")).
+		Set("Statement", hcl.Ref("var", "access_statements"))))
+
+	vpcCount := hcl.Conditional(hcl.Ref("local", "vpc_access"), hcl.Number(1), hcl.Number(0))
+	vpcID := hcl.Ref("data", "aws_vpc", "default[0]", "id")
+
+	body.Newline()
+	body.Comment("Functions using a database run in the default VPC to reach it")
+	body.Block("locals").Set("vpc_access", hcl.Binary(hcl.Call("length", hcl.Ref("var", "database_access")), ">", hcl.Number(0)))
+
+	body.Newline()
+	vpc := body.Block("data", "aws_vpc", "default")
+	vpc.Set("count", vpcCount)
+	vpc.Set("default", hcl.Bool(true))
+
+	body.Newline()
+	subnets := body.Block("data", "aws_subnets", "default")
+	subnets.Set("count", vpcCount)
+	subnets.Newline()
+	filter := subnets.Block("filter")
+	filter.Set("name", hcl.String("vpc-id"))
+	filter.Set("values", hcl.List(vpcID))
+
+	sg := resource(body, "", "aws_security_group", "lambda")
+	sg.Set("count", vpcCount)
+	sg.Newline()
+	sg.Set("name", hcl.Template(hcl.Ref("var", "function_name"), hcl.String("-lambda")))
+	sg.Set("description", hcl.String("Lambda function access to databases"))
+	sg.Set("vpc_id", vpcID)
+
+	vpcRole := resource(body, "", "aws_iam_role_policy_attachment", "vpc")
+	vpcRole.Set("count", vpcCount)
+	vpcRole.Newline()
+	vpcRole.Set("role", hcl.Ref("aws_iam_role", "lambda", "name"))
+	vpcRole.Set("policy_arn", hcl.String("arn:aws:iam::aws:policy/service-role/AWSLambdaVPCAccessExecutionRole"))
+
+	lambdaSG := hcl.Ref("aws_security_group", "lambda[0]", "id")
+	databaseSG := hcl.Ref("var", "database_access[count.index]", "security_group_id")
+	port := hcl.Ref("var", "database_access[count.index]", "port")
+	for _, rule := range []struct {
+		typ      string
+		sg, peer hcl.Expr
+	}{
+		{"aws_vpc_security_group_ingress_rule", databaseSG, lambdaSG},
+		{"aws_vpc_security_group_egress_rule", lambdaSG, databaseSG},
+	} {
+		r := resource(body, "", rule.typ, "database")
+		r.Set("count", hcl.Call("length", hcl.Ref("var", "database_access")))
+		r.Newline()
+		r.Set("security_group_id", rule.sg)
+		r.Set("referenced_security_group_id", rule.peer)
+		r.Set("ip_protocol", hcl.String("tcp"))
+		r.Set("from_port", port)
+		r.Set("to_port", port)
+	}
+
+	https := resource(body, "HTTPS to AWS APIs through the VPC endpoints in endpoints.tf", "aws_vpc_security_group_egress_rule", "https")
+	https.Set("count", vpcCount)
+	https.Newline()
+	https.Set("security_group_id", lambdaSG)
+	https.Set("cidr_ipv4", hcl.String("0.0.0.0/0"))
+	https.Set("ip_protocol", hcl.String("tcp"))
+	https.Set("from_port", hcl.Number(443))
+	https.Set("to_port", hcl.Number(443))
+}
+
+// vpcConfig places the function in the default VPC when it uses a database
+func vpcConfig(fn *hcl.Body) {
+	fn.Newline()
+	vpc := fn.Block("dynamic", "vpc_config")
+	vpc.Set("for_each", hcl.Conditional(hcl.Ref("local", "vpc_access"), hcl.List(hcl.Number(1)), hcl.List()))
+	vpc.Newline()
+	content := vpc.Block("content")
+	content.Set("subnet_ids", hcl.Ref("data", "aws_subnets", "default[0]", "ids"))
+	content.Set("security_group_ids", hcl.List(hcl.Ref("aws_security_group", "lambda[0]", "id")))
+}
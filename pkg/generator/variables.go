@@ -14,33 +14,25 @@
 
 package generator
 
-import "fmt"
+import "github.com/OplexTech/soloops-cli/pkg/hcl"
 
 func (g *Generator) generateVariables() error {
-	content := fmt.Sprintf(`variable "project_name" {
-  description = "Project name"
-  type        = string
-  default     = "%s"
-}
-
-variable "environment" {
-  description = "Environment name"
-  type        = string
-  default     = "%s"
-}
+	file := hcl.NewFile()
+	body := file.Body()
 
-variable "region" {
-  description = "Cloud region"
-  type        = string
-  default     = "%s"
-}
-
-variable "budget_usd" {
-  description = "Monthly budget in USD"
-  type        = number
-  default     = %.2f
-}
-`, g.Config.Project, g.Env.Name, g.Env.Region, g.Env.BudgetUSD)
+	variable := func(name, description, typ string, value hcl.Expr) {
+		if !body.Empty() {
+			body.Newline()
+		}
+		v := body.Block("variable", name)
+		v.Set("description", hcl.String(description))
+		v.Set("type", hcl.Ref(typ))
+		v.Set("default", value)
+	}
+	variable("project_name", "Project name", "string", hcl.String(g.Config.Project))
+	variable("environment", "Environment name", "string", hcl.String(g.Env.Name))
+	variable("region", "Cloud region", "string", hcl.String(g.Env.Region))
+	variable("budget_usd", "Monthly budget in USD", "number", hcl.Number(g.Env.BudgetUSD))
 
-	return g.writeFile("variables.tf", content)
+	return g.writeFile("variables.tf", file)
 }
@@ -0,0 +1,286 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hcl
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Expr is an attribute value
+type Expr interface {
+	// render returns the expression as it appears on a line indented by
+	// indent spaces; multi-line expressions close at that indentation
+	render(indent int) string
+}
+
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// IsIdentifier reports whether s can be used as a bare HCL name
+func IsIdentifier(s string) bool {
+	return identifier.MatchString(s)
+}
+
+type literal string
+
+func (l literal) render(int) string { return string(l) }
+
+type str string
+
+// String is a quoted string literal. Quotes, backslashes, control characters
+// and template sequences (${ and %{) are escaped.
+func String(s string) Expr {
+	return str(s)
+}
+
+func (s str) render(int) string { return quote(string(s)) }
+
+// Number is a number literal
+func Number(n float64) Expr {
+	return literal(strconv.FormatFloat(n, 'f', -1, 64))
+}
+
+// Bool is a bool literal
+func Bool(b bool) Expr {
+	return literal(strconv.FormatBool(b))
+}
+
+// Ref is a reference such as var.environment or aws_s3_bucket.site.arn,
+// built from its dot-separated parts
+func Ref(parts ...string) Expr {
+	return literal(strings.Join(parts, "."))
+}
+
+type template []Expr
+
+// Template is a quoted string interpolating its parts: String and Template
+// parts are inlined as text and every other part is wrapped in ${...}
+func Template(parts ...Expr) Expr {
+	return template(parts)
+}
+
+func (t template) render(indent int) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, part := range t {
+		if s, ok := part.(str); ok {
+			q := quote(string(s))
+			b.WriteString(q[1 : len(q)-1])
+			continue
+		}
+		if nested, ok := part.(template); ok {
+			r := nested.render(indent)
+			b.WriteString(r[1 : len(r)-1])
+			continue
+		}
+		b.WriteString("${" + part.render(indent) + "}")
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+type list []Expr
+
+// List is a tuple such as ["GET", "HEAD"]
+func List(items ...Expr) Expr {
+	return list(items)
+}
+
+// render keeps single-line items on one line and wraps a lone multi-line
+// item as [{ ... }]; several multi-line items get a line each
+func (l list) render(indent int) string {
+	items := make([]string, len(l))
+	multiLine := 0
+	for i, it := range l {
+		items[i] = it.render(indent)
+		if strings.Contains(items[i], "\n") {
+			multiLine++
+		}
+	}
+	if multiLine == 0 || len(items) == 1 {
+		return "[" + strings.Join(items, ", ") + "]"
+	}
+
+	var b strings.Builder
+	b.WriteString("[\n")
+	pad := strings.Repeat(" ", indent+2)
+	for _, it := range l {
+		b.WriteString(pad + it.render(indent+2) + ",\n")
+	}
+	b.WriteString(strings.Repeat(" ", indent) + "]")
+	return b.String()
+}
+
+// Object is an object such as { Name = "site" }, keeping its keys in the
+// order they are set
+type Object struct {
+	body Body
+}
+
+// NewObject creates an empty object
+func NewObject() *Object {
+	return &Object{}
+}
+
+// Set sets a key, quoting it when it is not a valid identifier
+func (o *Object) Set(key string, value Expr) *Object {
+	if !IsIdentifier(key) {
+		key = quote(key)
+	}
+	o.body.Set(key, value)
+	return o
+}
+
+func (o *Object) render(indent int) string {
+	if o.body.Empty() {
+		return "{}"
+	}
+	var b strings.Builder
+	b.WriteString("{\n")
+	o.body.render(&b, indent+2)
+	b.WriteString(strings.Repeat(" ", indent) + "}")
+	return b.String()
+}
+
+// Value converts a value decoded from YAML or JSON to an expression: strings,
+// numbers, bools and null become literals, lists tuples and maps objects with
+// sorted keys
+func Value(v interface{}) (Expr, error) {
+	switch v := v.(type) {
+	case nil:
+		return literal("null"), nil
+	case string:
+		return String(v), nil
+	case bool:
+		return Bool(v), nil
+	case int:
+		return literal(strconv.Itoa(v)), nil
+	case int64:
+		return literal(strconv.FormatInt(v, 10)), nil
+	case uint64:
+		return literal(strconv.FormatUint(v, 10)), nil
+	case float64:
+		return Number(v), nil
+	case []interface{}:
+		items := make(list, len(v))
+		for i, item := range v {
+			expr, err := Value(item)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = expr
+		}
+		return items, nil
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		object := NewObject()
+		for _, key := range keys {
+			expr, err := Value(v[key])
+			if err != nil {
+				return nil, err
+			}
+			object.Set(key, expr)
+		}
+		return object, nil
+	default:
+		return nil, fmt.Errorf("unsupported value of type %T", v)
+	}
+}
+
+type call struct {
+	name string
+	args []Expr
+}
+
+// Call is a function call such as jsonencode({ ... })
+func Call(name string, args ...Expr) Expr {
+	return call{name: name, args: args}
+}
+
+func (c call) render(indent int) string {
+	args := make([]string, len(c.args))
+	for i, arg := range c.args {
+		args[i] = arg.render(indent)
+	}
+	return c.name + "(" + strings.Join(args, ", ") + ")"
+}
+
+type conditional struct {
+	cond, then, otherwise Expr
+}
+
+// Conditional is a conditional expression such as var.enabled ? 1 : 0
+func Conditional(cond, then, otherwise Expr) Expr {
+	return conditional{cond: cond, then: then, otherwise: otherwise}
+}
+
+func (c conditional) render(indent int) string {
+	return c.cond.render(indent) + " ? " + c.then.render(indent) + " : " + c.otherwise.render(indent)
+}
+
+type binary struct {
+	left     Expr
+	operator string
+	right    Expr
+}
+
+// Binary is a binary operation such as var.name == ""
+func Binary(left Expr, operator string, right Expr) Expr {
+	return binary{left: left, operator: operator, right: right}
+}
+
+func (b binary) render(indent int) string {
+	return b.left.render(indent) + " " + b.operator + " " + b.right.render(indent)
+}
+
+// quote returns s as a quoted HCL string whose value is exactly s
+func quote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch c {
+		case '"', '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '$', '%':
+			b.WriteByte(c)
+			if i+1 < len(s) && s[i+1] == '{' {
+				b.WriteByte(c)
+			}
+		default:
+			if c < 0x20 || c == 0x7f {
+				fmt.Fprintf(&b, `\u%04x`, c)
+				continue
+			}
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
@@ -0,0 +1,180 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package hcl is a small model of the HCL that SoloOps generates. Files are
+// composed from blocks, attributes and typed expressions, and rendered in the
+// layout 'terraform fmt' produces with every string literal escaped, so values
+// from the manifest can never change the structure of the output.
+package hcl
+
+import (
+	"strings"
+)
+
+// File is a generated Terraform file
+type File struct {
+	body Body
+}
+
+// NewFile creates an empty file
+func NewFile() *File {
+	return &File{}
+}
+
+// Body returns the top-level body of the file
+func (f *File) Body() *Body {
+	return &f.body
+}
+
+// Bytes renders the file
+func (f *File) Bytes() []byte {
+	var b strings.Builder
+	f.body.render(&b, 0)
+	return []byte(b.String())
+}
+
+// Body is the content of a file, block or object: attributes, nested blocks,
+// comments and blank lines, in order
+type Body struct {
+	items []item
+}
+
+type item struct {
+	name    string // attribute name, already quoted when it is not an identifier
+	value   Expr   // set for attributes
+	block   *Block // set for nested blocks
+	comment string // set for comment lines
+	newline bool
+}
+
+// Block is a block such as resource "type" "name" { ... }
+type Block struct {
+	Type   string
+	Labels []string
+	Body   *Body
+}
+
+// Set sets an attribute, replacing the value in place if it is already set
+func (b *Body) Set(name string, value Expr) *Body {
+	for i := range b.items {
+		if b.items[i].value != nil && b.items[i].name == name {
+			b.items[i].value = value
+			return b
+		}
+	}
+	b.items = append(b.items, item{name: name, value: value})
+	return b
+}
+
+// Has reports whether an attribute is set
+func (b *Body) Has(name string) bool {
+	for _, it := range b.items {
+		if it.value != nil && it.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Block appends a nested block and returns its body
+func (b *Body) Block(typ string, labels ...string) *Body {
+	block := &Block{Type: typ, Labels: labels, Body: &Body{}}
+	b.items = append(b.items, item{block: block})
+	return block.Body
+}
+
+// Blocks returns the nested blocks in order
+func (b *Body) Blocks() []*Block {
+	var blocks []*Block
+	for _, it := range b.items {
+		if it.block != nil {
+			blocks = append(blocks, it.block)
+		}
+	}
+	return blocks
+}
+
+// Comment appends a comment; each line of text becomes a line starting with #
+func (b *Body) Comment(text string) *Body {
+	for _, line := range strings.Split(text, "\n") {
+		b.items = append(b.items, item{comment: strings.TrimRight("# "+line, " ")})
+	}
+	return b
+}
+
+// Newline appends a blank line
+func (b *Body) Newline() *Body {
+	b.items = append(b.items, item{newline: true})
+	return b
+}
+
+// Empty reports whether the body has no content
+func (b *Body) Empty() bool {
+	return len(b.items) == 0
+}
+
+// render writes the body at the given indentation. Like 'terraform fmt', the
+// equals signs of consecutive single-line attributes are aligned; blank
+// lines, comments, blocks and multi-line values end an aligned group.
+func (b *Body) render(out *strings.Builder, indent int) {
+	pad := strings.Repeat(" ", indent)
+
+	values := make([]string, len(b.items))
+	for i, it := range b.items {
+		if it.value != nil {
+			values[i] = it.value.render(indent)
+		}
+	}
+	singleLine := func(i int) bool {
+		return b.items[i].value != nil && !strings.Contains(values[i], "\n")
+	}
+
+	width := 0
+	for i, it := range b.items {
+		switch {
+		case it.value != nil:
+			if !singleLine(i) {
+				out.WriteString(pad + it.name + " = " + values[i] + "\n")
+				continue
+			}
+			if i == 0 || !singleLine(i-1) {
+				width = 0
+				for j := i; j < len(b.items) && singleLine(j); j++ {
+					width = max(width, len(b.items[j].name))
+				}
+			}
+			out.WriteString(pad + it.name + strings.Repeat(" ", width-len(it.name)) + " = " + values[i] + "\n")
+		case it.block != nil:
+			it.block.render(out, indent)
+		case it.newline:
+			out.WriteString("\n")
+		default:
+			out.WriteString(pad + it.comment + "\n")
+		}
+	}
+}
+
+func (b *Block) render(out *strings.Builder, indent int) {
+	out.WriteString(strings.Repeat(" ", indent) + b.Type)
+	for _, label := range b.Labels {
+		out.WriteString(" " + quote(label))
+	}
+	if b.Body.Empty() {
+		out.WriteString(" {}\n")
+		return
+	}
+	out.WriteString(" {\n")
+	b.Body.render(out, indent+2)
+	out.WriteString(strings.Repeat(" ", indent) + "}\n")
+}
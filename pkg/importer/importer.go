@@ -0,0 +1,394 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package importer
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/OplexTech/soloops-cli/pkg/config"
+	"github.com/OplexTech/soloops-cli/pkg/naming"
+)
+
+// Strategies for adopting existing resources
+const (
+	// StrategyImport emits import {} blocks, for a fresh SoloOps state
+	StrategyImport = "import"
+
+	// StrategyMoved emits moved {} blocks, for keeping the existing state
+	StrategyMoved = "moved"
+)
+
+// Adoption maps an existing resource onto the address SoloOps generates
+type Adoption struct {
+	From string // address in the existing configuration
+	To   string // address generated by SoloOps
+	ID   string // import ID
+
+	// Name is the resource's physical name and NameSuffix the part SoloOps
+	// derives it from along with the project and environment (see
+	// naming.Physical). A mismatch forces replacement.
+	Name       string
+	NameSuffix string
+}
+
+// Group is a set of existing resources recognised as one blueprint
+type Group struct {
+	Blueprint string
+	Kind      string
+	Adoptions []Adoption
+}
+
+// Proposal is the result of analysing a state file
+type Proposal struct {
+	Region     string
+	Blueprints map[string]config.Blueprint
+	Groups     []Group
+	Unmatched  []string // addresses not recognised as part of a blueprint
+}
+
+// Analyze recognises resource groups that match SoloOps blueprints:
+// Lambda + API Gateway (web_api) and S3 + CloudFront (static_site)
+func Analyze(state *State) *Proposal {
+	a := &analysis{
+		objs:     state.objects(),
+		claimed:  map[string]bool{},
+		names:    map[string]bool{},
+		proposal: &Proposal{Blueprints: map[string]config.Blueprint{}},
+	}
+
+	a.webAPIs()
+	a.staticSites()
+
+	for _, o := range a.objs {
+		if !a.claimed[o.address()] {
+			a.proposal.Unmatched = append(a.proposal.Unmatched, o.address())
+		}
+	}
+	sort.Strings(a.proposal.Unmatched)
+
+	return a.proposal
+}
+
+type analysis struct {
+	objs     []object
+	claimed  map[string]bool
+	names    map[string]bool
+	proposal *Proposal
+}
+
+// find returns the first unclaimed object of a type matching pred
+func (a *analysis) find(typ string, pred func(object) bool) (object, bool) {
+	for _, o := range a.objs {
+		if o.resource.Type == typ && !a.claimed[o.address()] && pred(o) {
+			return o, true
+		}
+	}
+	return object{}, false
+}
+
+func (a *analysis) all(typ string) []object {
+	var objs []object
+	for _, o := range a.objs {
+		if o.resource.Type == typ && !a.claimed[o.address()] {
+			objs = append(objs, o)
+		}
+	}
+	return objs
+}
+
+// adopt claims o and records it under the SoloOps address of the resource
+// labelled label in the blueprint's module
+func (a *analysis) adopt(g *Group, o object, label, id string) *Adoption {
+	a.claimed[o.address()] = true
+	g.Adoptions = append(g.Adoptions, Adoption{
+		From: o.address(),
+		To:   "module." + g.Blueprint + "." + o.resource.Type + "." + label,
+		ID:   id,
+	})
+	return &g.Adoptions[len(g.Adoptions)-1]
+}
+
+var nonIdentifier = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// accountSuffix matches the account ID SoloOps appends to bucket names
+var accountSuffix = regexp.MustCompile(`^-[0-9]{12}$`)
+
+// blueprintName derives a unique blueprint name from a resource label
+func (a *analysis) blueprintName(label string) string {
+	name := strings.Trim(nonIdentifier.ReplaceAllString(strings.ToLower(label), "_"), "_")
+	if name == "" || name[0] < 'a' || name[0] > 'z' {
+		name = "bp_" + name
+	}
+
+	unique := name
+	for i := 2; a.names[unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+	a.names[unique] = true
+	return unique
+}
+
+func (a *analysis) setRegion(region string) {
+	if a.proposal.Region == "" && region != "" {
+		a.proposal.Region = region
+	}
+}
+
+// webAPIs groups each Lambda function with its role and API Gateway HTTP API.
+// Resource labels must match the web_api module.
+func (a *analysis) webAPIs() {
+	for _, fn := range a.all("aws_lambda_function") {
+		api, ok := a.apiFor(fn)
+		if !ok {
+			continue
+		}
+
+		name := a.blueprintName(fn.resource.Name)
+		g := Group{Blueprint: name, Kind: config.KindWebAPI}
+		a.setRegion(regionFromARN(fn.str("arn")))
+
+		functionName := fn.str("function_name")
+		adoption := a.adopt(&g, fn, "this", functionName)
+		adoption.Name, adoption.NameSuffix = functionName, name
+
+		if role, ok := a.find("aws_iam_role", func(o object) bool { return o.str("arn") == fn.str("role") }); ok {
+			adoption := a.adopt(&g, role, "lambda", role.str("name"))
+			adoption.Name, adoption.NameSuffix = role.str("name"), name+"-lambda-role"
+
+			if attachment, ok := a.find("aws_iam_role_policy_attachment", func(o object) bool {
+				return o.str("role") == role.str("name") && strings.HasSuffix(o.str("policy_arn"), "/AWSLambdaBasicExecutionRole")
+			}); ok {
+				a.adopt(&g, attachment, "lambda", attachment.str("role")+"/"+attachment.str("policy_arn"))
+			}
+		}
+
+		apiID := api.str("id")
+		adoption = a.adopt(&g, api, "this", apiID)
+		adoption.Name, adoption.NameSuffix = api.str("name"), name
+
+		sameAPI := func(o object) bool { return o.str("api_id") == apiID }
+		if integration, ok := a.find("aws_apigatewayv2_integration", func(o object) bool {
+			return sameAPI(o) && integrates(o, fn)
+		}); ok {
+			a.adopt(&g, integration, "this", apiID+"/"+integration.str("id"))
+		}
+		if route, ok := a.find("aws_apigatewayv2_route", sameAPI); ok {
+			a.adopt(&g, route, "this", apiID+"/"+route.str("id"))
+		}
+		if stage, ok := a.find("aws_apigatewayv2_stage", sameAPI); ok {
+			a.adopt(&g, stage, "this", apiID+"/"+stage.str("name"))
+		}
+		if permission, ok := a.find("aws_lambda_permission", func(o object) bool {
+			target := o.str("function_name")
+			return target == functionName || target == fn.str("arn")
+		}); ok {
+			a.adopt(&g, permission, "apigateway", functionName+"/"+permission.str("statement_id"))
+		}
+
+		runtime := config.RuntimeFromLambda(fn.str("runtime"))
+		if runtime == "" {
+			runtime = config.SupportedRuntimes[0]
+		}
+
+		// Keep the function's size and timeout rather than SoloOps' defaults
+		var lambda config.Lambda
+		if memory := fn.num("memory_size"); memory != config.DefaultLambdaMemory && memory <= config.MaxLambdaMemory {
+			lambda.Memory = memory
+		}
+		if timeout := fn.num("timeout"); timeout != config.DefaultLambdaTimeout && timeout <= config.MaxLambdaTimeout {
+			lambda.Timeout = timeout
+		}
+
+		a.proposal.Blueprints[name] = config.Blueprint{
+			Type:    config.KindWebAPI,
+			Runtime: runtime,
+			Ingress: "edge",
+			Lambda:  lambda,
+		}
+		a.proposal.Groups = append(a.proposal.Groups, g)
+	}
+}
+
+// apiFor finds the HTTP API whose integration targets the function
+func (a *analysis) apiFor(fn object) (object, bool) {
+	integration, ok := a.find("aws_apigatewayv2_integration", func(o object) bool { return integrates(o, fn) })
+	if !ok {
+		return object{}, false
+	}
+	return a.find("aws_apigatewayv2_api", func(o object) bool { return o.str("id") == integration.str("api_id") })
+}
+
+func integrates(integration, fn object) bool {
+	uri := integration.str("integration_uri")
+	return uri != "" && (uri == fn.str("invoke_arn") || uri == fn.str("arn") || uri == fn.str("qualified_invoke_arn"))
+}
+
+// staticSites groups each CloudFront distribution with the S3 bucket it
+// serves. Resource labels must match the static_site module.
+func (a *analysis) staticSites() {
+	for _, dist := range a.all("aws_cloudfront_distribution") {
+		origin := dist.nested("origin", "domain_name")
+		bucket, ok := a.find("aws_s3_bucket", func(o object) bool {
+			return origin != "" && (origin == o.str("bucket_regional_domain_name") || origin == o.str("bucket_domain_name"))
+		})
+		if !ok {
+			continue
+		}
+
+		name := a.blueprintName(bucket.resource.Name)
+		g := Group{Blueprint: name, Kind: config.KindStaticSite}
+		bucketID := bucket.str("id")
+		a.setRegion(bucket.str("region"))
+
+		adoption := a.adopt(&g, bucket, "this", bucketID)
+		adoption.Name, adoption.NameSuffix = bucketID, name
+
+		sameBucket := func(o object) bool { return o.str("bucket") == bucketID }
+		for _, typ := range []string{
+			"aws_s3_bucket_website_configuration",
+			"aws_s3_bucket_public_access_block",
+			"aws_s3_bucket_policy",
+		} {
+			if o, ok := a.find(typ, sameBucket); ok {
+				a.adopt(&g, o, "this", bucketID)
+			}
+		}
+
+		a.adopt(&g, dist, "this", dist.str("id"))
+
+		oaiPath := dist.nested("origin", "s3_origin_config", "origin_access_identity")
+		if oai, ok := a.find("aws_cloudfront_origin_access_identity", func(o object) bool {
+			return oaiPath != "" && o.str("cloudfront_access_identity_path") == oaiPath
+		}); ok {
+			a.adopt(&g, oai, "this", oai.str("id"))
+		}
+
+		bp := config.Blueprint{Type: config.KindStaticSite}
+		if aliases, ok := dist.attrs["aliases"].([]interface{}); ok && len(aliases) > 0 {
+			bp.Domain, _ = aliases[0].(string)
+		}
+		a.proposal.Blueprints[name] = bp
+		a.proposal.Groups = append(a.proposal.Groups, g)
+	}
+}
+
+// Manifest builds a soloops.yaml configuration from the proposal
+func (p *Proposal) Manifest(project, env string, budget float64) *config.Config {
+	region := p.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	cfg := &config.Config{
+		APIVersion: config.CurrentAPIVersion,
+		Project:    project,
+		Cloud:      "aws",
+		Environments: []config.Environment{{
+			Name:       env,
+			Region:     region,
+			BudgetUSD:  budget,
+			Blueprints: p.Blueprints,
+		}},
+	}
+	if p.accountSuffixed(project, env) {
+		cfg.Naming = &config.Naming{AccountSuffix: true}
+	}
+	return cfg
+}
+
+// accountSuffixed reports whether an adopted bucket's name ends in the
+// account ID, in which case the manifest keeps that naming
+func (p *Proposal) accountSuffixed(project, env string) bool {
+	for _, g := range p.Groups {
+		for _, adoption := range g.Adoptions {
+			resourceType := adoptedType(adoption)
+			if adoption.NameSuffix == "" || !naming.RuleFor(resourceType).GlobalSuffix {
+				continue
+			}
+			rest, ok := strings.CutPrefix(adoption.Name, naming.PhysicalUnique(resourceType, project, env, adoption.NameSuffix))
+			if ok && accountSuffix.MatchString(rest) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func adoptedType(adoption Adoption) string {
+	parts := strings.Split(adoption.To, ".")
+	return parts[len(parts)-2]
+}
+
+// NameMismatches lists resources whose physical name differs from what
+// SoloOps generates; Terraform will replace them unless the name is pinned
+func (p *Proposal) NameMismatches(project, env string) []string {
+	suffixed := p.accountSuffixed(project, env)
+	var mismatches []string
+	for _, g := range p.Groups {
+		for _, adoption := range g.Adoptions {
+			if adoption.NameSuffix == "" {
+				continue
+			}
+			resourceType := adoptedType(adoption)
+			want := naming.Physical(resourceType, project, env, adoption.NameSuffix)
+			if suffixed && naming.RuleFor(resourceType).GlobalSuffix {
+				want = naming.PhysicalUnique(resourceType, project, env, adoption.NameSuffix)
+				if rest, ok := strings.CutPrefix(adoption.Name, want); ok && accountSuffix.MatchString(rest) {
+					continue
+				}
+				want += "-<account-id>"
+			}
+			if adoption.Name != want {
+				mismatches = append(mismatches, fmt.Sprintf("%s: %q (SoloOps generates %q)", adoption.To, adoption.Name, want))
+			}
+		}
+	}
+	return mismatches
+}
+
+// Render returns Terraform import {} or moved {} blocks adopting every
+// recognised resource
+func (p *Proposal) Render(strategy, source string) (string, error) {
+	if strategy != StrategyImport && strategy != StrategyMoved {
+		return "", fmt.Errorf("unknown strategy %q (supported: %s, %s)", strategy, StrategyImport, StrategyMoved)
+	}
+
+	var out strings.Builder
+	out.WriteString(fmt.Sprintf("# Generated by 'soloops import' from %s\n", source))
+	if strategy == StrategyImport {
+		out.WriteString("# Adopts existing resources into a new SoloOps state on the next apply\n")
+	} else {
+		out.WriteString("# Renames resources in the existing state to SoloOps addresses\n")
+	}
+
+	for _, g := range p.Groups {
+		out.WriteString(fmt.Sprintf("\n# Blueprint: %s (%s)\n", g.Blueprint, g.Kind))
+		for _, adoption := range g.Adoptions {
+			if strategy == StrategyImport {
+				out.WriteString(fmt.Sprintf("import {\n  to = %s\n  id = %q\n}\n\n", adoption.To, adoption.ID))
+				continue
+			}
+			if adoption.From == adoption.To {
+				continue
+			}
+			out.WriteString(fmt.Sprintf("moved {\n  from = %s\n  to   = %s\n}\n\n", adoption.From, adoption.To))
+		}
+	}
+
+	return strings.TrimRight(out.String(), "\n") + "\n", nil
+}
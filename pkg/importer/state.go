@@ -0,0 +1,147 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package importer proposes a soloops.yaml manifest for infrastructure that
+// is already managed by hand-written Terraform
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// State is the subset of a Terraform state file (format version 4) used for
+// importing
+type State struct {
+	Version   int        `json:"version"`
+	Resources []Resource `json:"resources"`
+}
+
+// Resource is a managed resource in the state
+type Resource struct {
+	Module    string     `json:"module,omitempty"`
+	Mode      string     `json:"mode"`
+	Type      string     `json:"type"`
+	Name      string     `json:"name"`
+	Instances []Instance `json:"instances"`
+}
+
+// Instance is one instance of a resource
+type Instance struct {
+	IndexKey   interface{}            `json:"index_key,omitempty"`
+	Attributes map[string]interface{} `json:"attributes"`
+}
+
+// Address returns the resource's Terraform address
+func (r Resource) Address() string {
+	addr := r.Type + "." + r.Name
+	if r.Module != "" {
+		addr = r.Module + "." + addr
+	}
+	return addr
+}
+
+// LoadState reads a terraform.tfstate file
+func LoadState(path string) (*State, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file: %w", err)
+	}
+
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse state file: %w", err)
+	}
+	if state.Version != 4 {
+		return nil, fmt.Errorf("unsupported state format version %d (expected 4)", state.Version)
+	}
+
+	return &state, nil
+}
+
+// object is a single resource instance with helpers for reading attributes
+type object struct {
+	resource Resource
+	attrs    map[string]interface{}
+}
+
+func (o object) address() string {
+	return o.resource.Address()
+}
+
+func (o object) str(key string) string {
+	if v, ok := o.attrs[key].(string); ok {
+		return v
+	}
+	return ""
+}
+
+func (o object) num(key string) int {
+	if v, ok := o.attrs[key].(float64); ok {
+		return int(v)
+	}
+	return 0
+}
+
+// nested returns a string from the first element of a nested block list,
+// e.g. nested("origin", "domain_name")
+func (o object) nested(keys ...string) string {
+	var cur interface{} = o.attrs
+	for _, key := range keys {
+		switch v := cur.(type) {
+		case map[string]interface{}:
+			cur = v[key]
+		case []interface{}:
+			if len(v) == 0 {
+				return ""
+			}
+			m, ok := v[0].(map[string]interface{})
+			if !ok {
+				return ""
+			}
+			cur = m[key]
+		default:
+			return ""
+		}
+	}
+	if list, ok := cur.([]interface{}); ok && len(list) > 0 {
+		cur = list[0]
+	}
+	s, _ := cur.(string)
+	return s
+}
+
+// objects flattens managed resources with a single instance. Resources using
+// count or for_each don't map onto blueprints and are skipped.
+func (s *State) objects() []object {
+	var objs []object
+	for _, r := range s.Resources {
+		if r.Mode != "managed" || len(r.Instances) != 1 || r.Instances[0].IndexKey != nil {
+			continue
+		}
+		objs = append(objs, object{resource: r, attrs: r.Instances[0].Attributes})
+	}
+	return objs
+}
+
+// regionFromARN extracts the region from an ARN
+func regionFromARN(arn string) string {
+	parts := strings.Split(arn, ":")
+	if len(parts) > 3 {
+		return parts[3]
+	}
+	return ""
+}
@@ -0,0 +1,222 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package naming validates blueprint names and derives the physical names of
+// the cloud resources generated for them. Each resource type has its own
+// rules for length and allowed characters. Names that already follow them are
+// used as is, so existing resources keep their names; names that break them
+// are normalised, and names that are too long are truncated with a hash so
+// they stay distinct.
+package naming
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// MaxKeyLength is the longest blueprint name accepted
+const MaxKeyLength = 64
+
+var keyPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`)
+
+// ValidateKey checks that a blueprint name can be used as a Terraform
+// identifier in resource addresses and output names
+func ValidateKey(key string) error {
+	if !keyPattern.MatchString(key) {
+		return fmt.Errorf("invalid name %q (start with a letter and use letters, digits, underscores and hyphens)", key)
+	}
+	if len(key) > MaxKeyLength {
+		return fmt.Errorf("name %q is longer than %d characters", key, MaxKeyLength)
+	}
+	return nil
+}
+
+// AccountSuffixLength is the length of the "-<account-id>" suffix appended to
+// names that must be globally unique
+const AccountSuffixLength = 13
+
+// Rule describes the names a resource type accepts
+type Rule struct {
+	MaxLength    int
+	Chars        string // allowed characters besides ASCII letters and digits
+	Lowercase    bool
+	StartLetter  bool
+	NoDoubleDash bool // "--" is not allowed
+
+	// GlobalSuffix marks names that must be unique across all accounts; the
+	// generator can append the account ID (see PhysicalUnique)
+	GlobalSuffix bool
+
+	// Namespace groups resource types whose names share one namespace;
+	// empty means the resource type itself
+	Namespace string
+}
+
+// rules holds the naming rules of the resource types SoloOps generates
+var rules = map[string]Rule{
+	"aws_lambda_function":         {MaxLength: 64, Chars: "-_"},
+	"aws_iam_role":                {MaxLength: 64, Chars: "+=,.@-_"},
+	"aws_apigatewayv2_api":        {MaxLength: 128, Chars: "-_"},
+	"aws_wafv2_web_acl":           {MaxLength: 128, Chars: "-_"},
+	"aws_cloudwatch_metric_alarm": {MaxLength: 255, Chars: "-_."},
+	"aws_budgets_budget":          {MaxLength: 100, Chars: "-_."},
+	"aws_s3_bucket":               {MaxLength: 63, Chars: "-", Lowercase: true, GlobalSuffix: true},
+	"aws_dynamodb_table":          {MaxLength: 255, Chars: "-_."},
+	"aws_rds_cluster":             {MaxLength: 63, Chars: "-", Lowercase: true, StartLetter: true, NoDoubleDash: true},
+	"aws_rds_cluster_instance":    {MaxLength: 63, Chars: "-", Lowercase: true, StartLetter: true, NoDoubleDash: true, Namespace: "db_instance"},
+	"aws_db_instance":             {MaxLength: 63, Chars: "-", Lowercase: true, StartLetter: true, NoDoubleDash: true, Namespace: "db_instance"},
+	"aws_db_snapshot":             {MaxLength: 255, Chars: "-", Lowercase: true, StartLetter: true, NoDoubleDash: true},
+}
+
+// defaultRule applies to resource types without a rule of their own
+var defaultRule = Rule{MaxLength: 64, Chars: "-_"}
+
+// RuleFor returns the naming rule of a resource type
+func RuleFor(resourceType string) Rule {
+	if rule, ok := rules[resourceType]; ok {
+		return rule
+	}
+	return defaultRule
+}
+
+// Physical derives a compliant name for a resource from its parts, usually
+// the project, environment and blueprint-specific suffix, joined by hyphens.
+// A joined name that already complies is returned unchanged, as SoloOps
+// generated it before these rules existed, so stateful resources such as
+// buckets and databases are never renamed. Otherwise disallowed characters
+// become hyphens, and names over the length limit are truncated and suffixed
+// with a hash of the full name.
+func Physical(resourceType string, parts ...string) string {
+	return derive(RuleFor(resourceType), 0, parts)
+}
+
+// PhysicalUnique derives the name of a resource that must be unique across
+// all accounts, leaving room for the "-<account-id>" suffix the generator
+// appends. Adding the suffix renames existing resources, so it is opt-in.
+func PhysicalUnique(resourceType string, parts ...string) string {
+	rule := RuleFor(resourceType)
+	if !rule.GlobalSuffix {
+		return derive(rule, 0, parts)
+	}
+	return derive(rule, AccountSuffixLength, parts)
+}
+
+func derive(rule Rule, reserved int, parts []string) string {
+	full := strings.Join(parts, "-")
+	limit := rule.MaxLength - reserved
+	if rule.Valid(full) && len(full) <= limit {
+		return full
+	}
+
+	name := full
+	if rule.Lowercase {
+		name = strings.ToLower(name)
+	}
+	name = strings.Map(func(r rune) rune {
+		if r < 128 && (isLetter(byte(r)) || r >= '0' && r <= '9' || strings.ContainsRune(rule.Chars, r)) {
+			return r
+		}
+		return '-'
+	}, name)
+	for strings.Contains(name, "--") {
+		name = strings.ReplaceAll(name, "--", "-")
+	}
+	name = strings.Trim(name, "-")
+	if rule.StartLetter && (name == "" || !isLetter(name[0])) {
+		name = "r-" + name
+	}
+
+	if len(name) > limit {
+		sum := sha256.Sum256([]byte(full))
+		hash := hex.EncodeToString(sum[:])[:8]
+		name = strings.TrimRight(name[:limit-len(hash)-1], "-") + "-" + hash
+	}
+	return name
+}
+
+// Valid reports whether a name follows the rule as it is
+func (r Rule) Valid(name string) bool {
+	if name == "" || len(name) > r.MaxLength || name[0] == '-' || name[len(name)-1] == '-' {
+		return false
+	}
+	if r.StartLetter && !isLetter(name[0]) {
+		return false
+	}
+	if r.NoDoubleDash && strings.Contains(name, "--") {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= '0' && c <= '9', strings.IndexByte(r.Chars, c) >= 0:
+		case c >= 'A' && c <= 'Z' && !r.Lowercase:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// Name is a physical name derived for a blueprint's resource
+type Name struct {
+	Blueprint    string
+	ResourceType string
+	Value        string
+}
+
+// Conflicts returns an error listing physical names that more than one
+// blueprint would use for resources in the same namespace
+func Conflicts(names []Name) error {
+	claimed := map[string][]Name{}
+	for _, name := range names {
+		namespace := RuleFor(name.ResourceType).Namespace
+		if namespace == "" {
+			namespace = name.ResourceType
+		}
+		key := namespace + "/" + strings.ToLower(name.Value)
+		claimed[key] = append(claimed[key], name)
+	}
+
+	var conflicts []string
+	for _, names := range claimed {
+		blueprints := map[string]bool{}
+		for _, name := range names {
+			blueprints[name.Blueprint] = true
+		}
+		if len(blueprints) < 2 {
+			continue
+		}
+		list := make([]string, 0, len(blueprints))
+		for blueprint := range blueprints {
+			list = append(list, blueprint)
+		}
+		sort.Strings(list)
+		conflicts = append(conflicts, fmt.Sprintf("blueprints %s all derive %s name %q",
+			strings.Join(list, ", "), names[0].ResourceType, names[0].Value))
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	sort.Strings(conflicts)
+	return fmt.Errorf("physical name conflicts (rename one of the blueprints):\n  %s", strings.Join(conflicts, "\n  "))
+}
@@ -0,0 +1,185 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Output formats for summaries
+const (
+	FormatText     = "text"
+	FormatJSON     = "json"
+	FormatMarkdown = "markdown"
+)
+
+// Formats lists the supported summary formats
+var Formats = []string{FormatText, FormatJSON, FormatMarkdown}
+
+var actionSymbols = map[string]string{
+	ActionCreate:  "+",
+	ActionUpdate:  "~",
+	ActionDelete:  "-",
+	ActionReplace: "-/+",
+}
+
+// Render formats the summary as text, json or markdown
+func (s *Summary) Render(format string) (string, error) {
+	switch format {
+	case FormatText:
+		return s.text(), nil
+	case FormatJSON:
+		data, err := json.MarshalIndent(s, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data) + "\n", nil
+	case FormatMarkdown:
+		return s.markdown(), nil
+	}
+	return "", fmt.Errorf("unknown output format %q (supported: %s)", format, strings.Join(Formats, ", "))
+}
+
+func countsText(c Counts) string {
+	text := fmt.Sprintf("+%d create, ~%d update, -%d destroy", c.Create, c.Update, c.Delete)
+	if c.Replace > 0 {
+		text += fmt.Sprintf(", -/+%d replace", c.Replace)
+	}
+	return text
+}
+
+func describe(c Change) string {
+	var notes []string
+	if c.Action == ActionReplace {
+		notes = append(notes, "replaced")
+	}
+	if c.Action == ActionDelete {
+		notes = append(notes, "destroyed")
+	}
+	if c.DataStore {
+		notes = append(notes, "data store")
+	}
+	return strings.Join(notes, ", ")
+}
+
+func (s *Summary) text() string {
+	var out strings.Builder
+	out.WriteString("Plan summary:\n")
+
+	if s.Totals.Total() == 0 {
+		out.WriteString("  No changes\n")
+		return out.String()
+	}
+
+	width := 0
+	for _, g := range s.Groups {
+		if len(g.Blueprint) > width {
+			width = len(g.Blueprint)
+		}
+	}
+	for _, g := range s.Groups {
+		out.WriteString(fmt.Sprintf("  %-*s  %s\n", width+1, g.Blueprint+":", countsText(g.Counts)))
+	}
+	out.WriteString(fmt.Sprintf("\n  Total: %s\n", countsText(s.Totals)))
+
+	if destructive := s.Destructive(); len(destructive) > 0 {
+		out.WriteString("\n⚠️  Destructive changes:\n")
+		for _, c := range destructive {
+			out.WriteString(fmt.Sprintf("  %-3s %s (%s)\n", actionSymbols[c.Action], c.Address, describe(c)))
+		}
+	}
+
+	return out.String()
+}
+
+func (s *Summary) markdown() string {
+	var out strings.Builder
+	out.WriteString("### SoloOps plan\n\n")
+
+	if s.Totals.Total() == 0 {
+		out.WriteString("No changes.\n")
+		return out.String()
+	}
+
+	out.WriteString("| Blueprint | Create | Update | Destroy | Replace |\n")
+	out.WriteString("|-----------|-------:|-------:|--------:|--------:|\n")
+	for _, g := range s.Groups {
+		out.WriteString(fmt.Sprintf("| %s | %d | %d | %d | %d |\n",
+			g.Blueprint, g.Counts.Create, g.Counts.Update, g.Counts.Delete, g.Counts.Replace))
+	}
+	out.WriteString(fmt.Sprintf("| **Total** | **%d** | **%d** | **%d** | **%d** |\n",
+		s.Totals.Create, s.Totals.Update, s.Totals.Delete, s.Totals.Replace))
+
+	if destructive := s.Destructive(); len(destructive) > 0 {
+		out.WriteString("\n#### ⚠️ Destructive changes\n\n")
+		for _, c := range destructive {
+			out.WriteString(fmt.Sprintf("- `%s` %s (%s)\n", c.Address, c.Action, describe(c)))
+		}
+	}
+
+	return out.String()
+}
+
+var driftDescriptions = map[string]string{
+	ActionCreate:  "created outside Terraform",
+	ActionUpdate:  "changed outside Terraform",
+	ActionDelete:  "deleted outside Terraform",
+	ActionReplace: "replaced outside Terraform",
+}
+
+// RenderDrift formats a drift summary as text, json or markdown
+func (s *Summary) RenderDrift(format, environment string) (string, error) {
+	switch format {
+	case FormatJSON:
+		return s.Render(FormatJSON)
+	case FormatText, FormatMarkdown:
+	default:
+		return "", fmt.Errorf("unknown output format %q (supported: %s)", format, strings.Join(Formats, ", "))
+	}
+
+	total := s.Totals.Total()
+	var out strings.Builder
+
+	if format == FormatMarkdown {
+		out.WriteString(fmt.Sprintf("### SoloOps drift: %s\n\n", environment))
+		if total == 0 {
+			out.WriteString("No drift.\n")
+			return out.String(), nil
+		}
+		out.WriteString("| Blueprint | Resource | Drift |\n")
+		out.WriteString("|-----------|----------|-------|\n")
+		for _, g := range s.Groups {
+			for _, c := range g.Changes {
+				out.WriteString(fmt.Sprintf("| %s | `%s` | %s |\n", g.Blueprint, c.Address, driftDescriptions[c.Action]))
+			}
+		}
+		return out.String(), nil
+	}
+
+	if total == 0 {
+		out.WriteString(fmt.Sprintf("✓ No drift in %s\n", environment))
+		return out.String(), nil
+	}
+	out.WriteString(fmt.Sprintf("⚠️  Drift detected in %s (%d resources):\n", environment, total))
+	for _, g := range s.Groups {
+		out.WriteString(fmt.Sprintf("  %s:\n", g.Blueprint))
+		for _, c := range g.Changes {
+			out.WriteString(fmt.Sprintf("    %-3s %s (%s)\n", actionSymbols[c.Action], c.Address, driftDescriptions[c.Action]))
+		}
+	}
+	return out.String(), nil
+}
@@ -0,0 +1,150 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package plan saves Terraform plans and summarizes their changes
+package plan
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultFile is where preview saves its plan
+const DefaultFile = "infra/soloops.tfplan"
+
+// Meta records what a saved plan was made from
+type Meta struct {
+	Environment string    `json:"environment"`
+	Manifest    string    `json:"manifest"`
+	Hash        string    `json:"hash"` // Fingerprint of the manifest and generated files
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// JSONFile returns the path of the plan's JSON rendering
+func JSONFile(planFile string) string {
+	return planFile + ".json"
+}
+
+// MetaFile returns the path of the plan's metadata
+func MetaFile(planFile string) string {
+	return planFile + ".meta.json"
+}
+
+// Fingerprint hashes the manifest, the target environment and every .tf file
+// under dirs, the generated directory and the shared modules it uses, so any
+// change to what Terraform would plan is detected
+func Fingerprint(manifest, environment string, dirs ...string) (string, error) {
+	h := sha256.New()
+	fmt.Fprintf(h, "environment %s\n", environment)
+
+	data, err := os.ReadFile(manifest)
+	if err != nil {
+		return "", fmt.Errorf("failed to read manifest: %w", err)
+	}
+	fmt.Fprintf(h, "manifest %d\n", len(data))
+	h.Write(data)
+
+	var files []string
+	for _, dir := range dirs {
+		err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() && d.Name() == ".terraform" {
+				return filepath.SkipDir
+			}
+			if !d.IsDir() && strings.HasSuffix(path, ".tf") {
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to read generated files: %w", err)
+		}
+	}
+	sort.Strings(files)
+
+	for _, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		fmt.Fprintf(h, "file %s %d\n", filepath.ToSlash(path), len(data))
+		h.Write(data)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// WriteMeta saves a plan's metadata next to it
+func WriteMeta(planFile string, meta Meta) error {
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(MetaFile(planFile), append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("failed to write plan metadata: %w", err)
+	}
+	return nil
+}
+
+// ReadMeta loads a plan's metadata
+func ReadMeta(planFile string) (*Meta, error) {
+	data, err := os.ReadFile(MetaFile(planFile))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%s was not saved by 'soloops preview' (missing %s)", planFile, MetaFile(planFile))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan metadata: %w", err)
+	}
+
+	var meta Meta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, fmt.Errorf("failed to parse plan metadata: %w", err)
+	}
+	return &meta, nil
+}
+
+// Verify checks that the manifest and generated files still match the plan
+func Verify(planFile, manifest, environment string, dirs ...string) (*Meta, error) {
+	if _, err := os.Stat(planFile); err != nil {
+		return nil, fmt.Errorf("plan file not found: %s", planFile)
+	}
+
+	meta, err := ReadMeta(planFile)
+	if err != nil {
+		return nil, err
+	}
+	if environment != "" && meta.Environment != environment {
+		return nil, fmt.Errorf("plan was made for environment %q, not %q", meta.Environment, environment)
+	}
+
+	hash, err := Fingerprint(manifest, meta.Environment, dirs...)
+	if err != nil {
+		return nil, err
+	}
+	if hash != meta.Hash {
+		return nil, fmt.Errorf("%s or the generated files changed since the plan was made; run 'soloops preview' again", manifest)
+	}
+
+	return meta, nil
+}
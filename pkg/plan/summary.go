@@ -0,0 +1,253 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Change actions
+const (
+	ActionCreate  = "create"
+	ActionUpdate  = "update"
+	ActionDelete  = "delete"
+	ActionReplace = "replace"
+)
+
+// SharedGroup collects resources that don't belong to a blueprint, such as
+// the budget and secret lookups
+const SharedGroup = "(shared)"
+
+// dataStoreTypes are resources holding data that is lost when they are
+// deleted or replaced
+var dataStoreTypes = map[string]bool{
+	"aws_s3_bucket":                      true,
+	"aws_db_instance":                    true,
+	"aws_rds_cluster":                    true,
+	"aws_rds_cluster_instance":           true,
+	"aws_dynamodb_table":                 true,
+	"aws_efs_file_system":                true,
+	"aws_elasticache_cluster":            true,
+	"aws_elasticache_replication_group":  true,
+	"google_sql_database_instance":       true,
+	"google_storage_bucket":              true,
+	"azurerm_storage_account":            true,
+	"azurerm_postgresql_flexible_server": true,
+}
+
+// Counts tallies changes by action
+type Counts struct {
+	Create  int `json:"create"`
+	Update  int `json:"update"`
+	Delete  int `json:"delete"`
+	Replace int `json:"replace"`
+}
+
+// Total returns the number of changed resources
+func (c Counts) Total() int {
+	return c.Create + c.Update + c.Delete + c.Replace
+}
+
+func (c *Counts) add(action string) {
+	switch action {
+	case ActionCreate:
+		c.Create++
+	case ActionUpdate:
+		c.Update++
+	case ActionDelete:
+		c.Delete++
+	case ActionReplace:
+		c.Replace++
+	}
+}
+
+// Change is a planned change to one resource
+type Change struct {
+	Address   string `json:"address"`
+	Type      string `json:"type"`
+	Action    string `json:"action"`
+	DataStore bool   `json:"data_store,omitempty"`
+}
+
+// Destructive reports whether the change loses a resource or its data
+func (c Change) Destructive() bool {
+	return c.Action == ActionReplace || c.Action == ActionDelete ||
+		(c.DataStore && c.Action != ActionCreate)
+}
+
+// Group is the set of changes belonging to one blueprint
+type Group struct {
+	Blueprint string   `json:"blueprint"`
+	Counts    Counts   `json:"counts"`
+	Changes   []Change `json:"changes"`
+}
+
+// Summary is a plan's changes grouped by blueprint
+type Summary struct {
+	Groups []Group `json:"groups"`
+	Totals Counts  `json:"totals"`
+}
+
+// Destructive returns the changes that delete or replace resources, or touch
+// data stores
+func (s *Summary) Destructive() []Change {
+	var changes []Change
+	for _, g := range s.Groups {
+		for _, c := range g.Changes {
+			if c.Destructive() {
+				changes = append(changes, c)
+			}
+		}
+	}
+	return changes
+}
+
+// resourceChange is an entry of resource_changes or resource_drift in
+// 'terraform show -json' output
+type resourceChange struct {
+	Address       string `json:"address"`
+	ModuleAddress string `json:"module_address"`
+	Mode          string `json:"mode"`
+	Type          string `json:"type"`
+	Name          string `json:"name"`
+	Change        struct {
+		Actions []string `json:"actions"`
+	} `json:"change"`
+}
+
+// jsonPlan is the subset of 'terraform show -json' output used for summaries
+type jsonPlan struct {
+	ResourceChanges []resourceChange `json:"resource_changes"`
+	ResourceDrift   []resourceChange `json:"resource_drift"`
+}
+
+func parsePlan(planJSON []byte) (*jsonPlan, error) {
+	var p jsonPlan
+	if err := json.Unmarshal(planJSON, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse plan JSON: %w", err)
+	}
+	return &p, nil
+}
+
+// Summarize groups the changes in a JSON plan by the blueprint that owns
+// each resource
+func Summarize(planJSON []byte, blueprints []string) (*Summary, error) {
+	p, err := parsePlan(planJSON)
+	if err != nil {
+		return nil, err
+	}
+	return summarize(p.ResourceChanges, blueprints), nil
+}
+
+// SummarizeDrift groups the resources changed outside Terraform, as found by
+// a refresh-only plan, by the blueprint that owns them. Updates are resources
+// modified in place and deletes are resources removed outside Terraform.
+func SummarizeDrift(planJSON []byte, blueprints []string) (*Summary, error) {
+	p, err := parsePlan(planJSON)
+	if err != nil {
+		return nil, err
+	}
+	return summarize(p.ResourceDrift, blueprints), nil
+}
+
+func summarize(changes []resourceChange, blueprints []string) *Summary {
+	groups := map[string]*Group{}
+	summary := &Summary{}
+
+	for _, rc := range changes {
+		if rc.Mode == "data" {
+			continue
+		}
+		action := actionOf(rc.Change.Actions)
+		if action == "" {
+			continue
+		}
+
+		owner := Owner(rc.ModuleAddress, rc.Name, blueprints)
+		g, ok := groups[owner]
+		if !ok {
+			g = &Group{Blueprint: owner}
+			groups[owner] = g
+		}
+
+		g.Changes = append(g.Changes, Change{
+			Address:   rc.Address,
+			Type:      rc.Type,
+			Action:    action,
+			DataStore: dataStoreTypes[rc.Type],
+		})
+		g.Counts.add(action)
+		summary.Totals.add(action)
+	}
+
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		if name != SharedGroup {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if _, ok := groups[SharedGroup]; ok {
+		names = append(names, SharedGroup)
+	}
+	for _, name := range names {
+		summary.Groups = append(summary.Groups, *groups[name])
+	}
+
+	return summary
+}
+
+// actionOf maps Terraform's action list onto a single action; no-ops and
+// reads return ""
+func actionOf(actions []string) string {
+	switch strings.Join(actions, ",") {
+	case "create":
+		return ActionCreate
+	case "update":
+		return ActionUpdate
+	case "delete":
+		return ActionDelete
+	case "delete,create", "create,delete":
+		return ActionReplace
+	}
+	return ""
+}
+
+// Owner returns the blueprint a resource belongs to. Resources in a
+// module.<blueprint> module belong to it; otherwise the longest blueprint
+// name that is the resource name or prefixes it ("api" owns "api_lambda_role")
+// wins.
+func Owner(moduleAddress, name string, blueprints []string) string {
+	if moduleAddress != "" {
+		first := strings.TrimPrefix(strings.SplitN(moduleAddress, ".module.", 2)[0], "module.")
+		for _, bp := range blueprints {
+			if bp == first {
+				return bp
+			}
+		}
+		return SharedGroup
+	}
+
+	owner := SharedGroup
+	for _, bp := range blueprints {
+		if (name == bp || strings.HasPrefix(name, bp+"_")) && (owner == SharedGroup || len(bp) > len(owner)) {
+			owner = bp
+		}
+	}
+	return owner
+}
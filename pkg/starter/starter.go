@@ -0,0 +1,326 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package starter scaffolds application code alongside a soloops.yaml
+// manifest from bundled or local project templates
+package starter
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	soloops "github.com/OplexTech/soloops-cli"
+	"gopkg.in/yaml.v3"
+)
+
+// ManifestFile is the destination name of a starter-provided manifest
+const ManifestFile = "soloops.yaml"
+
+// templateSuffix marks files rendered with text/template; the suffix is
+// dropped from the destination name
+const templateSuffix = ".tmpl"
+
+// File maps a file or directory in a starter to a destination in the project.
+// Replace substitutes text in the copied files with templates rendered with
+// the project's Data, so the source files stay usable as they are.
+type File struct {
+	Src     string            `yaml:"src"`
+	Dest    string            `yaml:"dest"`
+	Replace map[string]string `yaml:"replace"`
+}
+
+// Starter describes a project template
+type Starter struct {
+	Name        string   `yaml:"-"`
+	Description string   `yaml:"description"`
+	Blueprints  []string `yaml:"blueprints"` // [name=]type[:option], as for 'init --blueprint'
+	Files       []File   `yaml:"files"`
+
+	fsys fs.FS
+}
+
+// Data is passed to templated files
+type Data struct {
+	Project string
+}
+
+// Builtin returns the starters bundled with the CLI
+func Builtin() []Starter {
+	root, err := fs.Sub(soloops.Templates, "infra-templates")
+	if err != nil {
+		panic(err) // the embedded tree is fixed at build time
+	}
+
+	// The bundled files are complete examples on their own; scaffolding
+	// swaps their placeholder names for the project's
+	packageJSON := File{
+		Src:     "serverless-api/package.json",
+		Dest:    "api/package.json",
+		Replace: map[string]string{`"name": "serverless-api"`: `"name": "{{ .Project }}-api"`},
+	}
+	website := File{
+		Src:     "static-site/example-website",
+		Dest:    "site",
+		Replace: map[string]string{"My Static Site": "{{ .Project }}"},
+	}
+
+	return []Starter{
+		{
+			Name:        "serverless-api-python",
+			Description: "Python Lambda behind API Gateway",
+			Blueprints:  []string{"api=web_api:python3.12"},
+			Files: []File{
+				{Src: "serverless-api/example-handler.py", Dest: "api/index.py"},
+				{Src: "serverless-api/requirements.txt", Dest: "api/requirements.txt"},
+			},
+			fsys: root,
+		},
+		{
+			Name:        "serverless-api-node",
+			Description: "Node.js Lambda behind API Gateway",
+			Blueprints:  []string{"api=web_api:node18"},
+			Files: []File{
+				{Src: "serverless-api/example-handler.js", Dest: "api/index.js"},
+				packageJSON,
+			},
+			fsys: root,
+		},
+		{
+			Name:        "static-site",
+			Description: "Static website on S3 and CloudFront",
+			Blueprints:  []string{"site=static_site:example.com"},
+			Files: []File{
+				website,
+			},
+			fsys: root,
+		},
+		{
+			Name:        "full-stack-node",
+			Description: "Static website with a Node.js API",
+			Blueprints:  []string{"site=static_site:example.com", "api=web_api:node18"},
+			Files: []File{
+				website,
+				{Src: "serverless-api/example-handler.js", Dest: "api/index.js"},
+				packageJSON,
+			},
+			fsys: root,
+		},
+	}
+}
+
+// LoadRepository loads third-party starters from a local directory. Each
+// subdirectory containing a starter.yaml is a starter; without a files list,
+// everything else in the subdirectory is copied into the project root.
+func LoadRepository(dir string) ([]Starter, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template repository: %w", err)
+	}
+
+	var starters []Starter
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		specPath := filepath.Join(dir, entry.Name(), "starter.yaml")
+		data, err := os.ReadFile(specPath)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", specPath, err)
+		}
+
+		var s Starter
+		if err := yaml.Unmarshal(data, &s); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", specPath, err)
+		}
+		s.Name = entry.Name()
+		s.fsys = os.DirFS(filepath.Join(dir, entry.Name()))
+		if len(s.Files) == 0 {
+			s.Files = []File{{Src: ".", Dest: "."}}
+		}
+
+		starters = append(starters, s)
+	}
+
+	return starters, nil
+}
+
+// Find looks a starter up by name in the given repositories first, then
+// among the built-in starters
+func Find(name string, repositories []string) (*Starter, error) {
+	available, err := List(repositories)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for i := range available {
+		if available[i].Name == name {
+			return &available[i], nil
+		}
+		names = append(names, available[i].Name)
+	}
+
+	return nil, fmt.Errorf("unknown template %q (available: %s)", name, strings.Join(names, ", "))
+}
+
+// List returns every available starter; repository starters shadow built-in
+// ones with the same name
+func List(repositories []string) ([]Starter, error) {
+	var all []Starter
+	seen := map[string]bool{}
+
+	for _, repo := range repositories {
+		starters, err := LoadRepository(repo)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range starters {
+			if !seen[s.Name] {
+				seen[s.Name] = true
+				all = append(all, s)
+			}
+		}
+	}
+	for _, s := range Builtin() {
+		if !seen[s.Name] {
+			seen[s.Name] = true
+			all = append(all, s)
+		}
+	}
+
+	return all, nil
+}
+
+// Render returns the project files, keyed by slash-separated destination
+// path. Files ending in .tmpl are rendered with data and lose the suffix.
+func (s *Starter) Render(data Data) (map[string][]byte, error) {
+	files := map[string][]byte{}
+
+	for _, mapping := range s.Files {
+		err := fs.WalkDir(s.fsys, mapping.Src, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || (mapping.Src == "." && p == "starter.yaml") {
+				return nil
+			}
+
+			rel := p
+			if mapping.Src != "." {
+				rel = strings.TrimPrefix(strings.TrimPrefix(p, mapping.Src), "/")
+			}
+			dest := path.Join(mapping.Dest, rel)
+
+			content, err := fs.ReadFile(s.fsys, p)
+			if err != nil {
+				return err
+			}
+
+			if content, err = replace(content, mapping.Replace, data); err != nil {
+				return err
+			}
+			if strings.HasSuffix(dest, templateSuffix) {
+				dest = strings.TrimSuffix(dest, templateSuffix)
+				if content, err = render(p, content, data); err != nil {
+					return err
+				}
+			}
+
+			files[dest] = content
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("template %s: %w", s.Name, err)
+		}
+	}
+
+	return files, nil
+}
+
+// replace substitutes each key of replacements in content with its value
+// rendered with data
+func replace(content []byte, replacements map[string]string, data Data) ([]byte, error) {
+	olds := make([]string, 0, len(replacements))
+	for old := range replacements {
+		olds = append(olds, old)
+	}
+	sort.Strings(olds)
+
+	for _, old := range olds {
+		value, err := render("replace "+old, []byte(replacements[old]), data)
+		if err != nil {
+			return nil, err
+		}
+		content = bytes.ReplaceAll(content, []byte(old), value)
+	}
+	return content, nil
+}
+
+func render(name string, content []byte, data Data) ([]byte, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, data); err != nil {
+		return nil, fmt.Errorf("failed to render %s: %w", name, err)
+	}
+	return out.Bytes(), nil
+}
+
+// WriteFiles writes rendered files under dir, refusing to overwrite existing
+// files unless force is set
+func WriteFiles(dir string, files map[string][]byte, force bool) ([]string, error) {
+	paths := make([]string, 0, len(files))
+	for p := range files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	if !force {
+		for _, p := range paths {
+			if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(p))); err == nil {
+				return nil, fmt.Errorf("file already exists: %s (use --force to overwrite)", p)
+			}
+		}
+	}
+
+	for _, p := range paths {
+		target := filepath.Join(dir, filepath.FromSlash(p))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create directory for %s: %w", p, err)
+		}
+		mode := os.FileMode(0644)
+		if strings.HasSuffix(p, ".sh") {
+			mode = 0755
+		}
+		if err := os.WriteFile(target, files[p], mode); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", p, err)
+		}
+	}
+
+	return paths, nil
+}
@@ -0,0 +1,166 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tf
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// Terraform runs the terraform binary
+type Terraform struct {
+	Binary string // defaults to "terraform" on the PATH
+	Options
+}
+
+// New creates a Runner that executes the terraform binary
+func New(opts Options) *Terraform {
+	return &Terraform{Binary: "terraform", Options: opts}
+}
+
+// Init runs 'terraform init'
+func (t *Terraform) Init(ctx context.Context) error {
+	return t.run(ctx, "init", "-input=false")
+}
+
+// Validate runs 'terraform validate'
+func (t *Terraform) Validate(ctx context.Context) error {
+	return t.run(ctx, "validate")
+}
+
+// Plan runs 'terraform plan' with -detailed-exitcode, which exits 2 when
+// there are changes
+func (t *Terraform) Plan(ctx context.Context, opts PlanOptions) (bool, error) {
+	args := []string{"plan", "-input=false", "-detailed-exitcode"}
+	if opts.Out != "" {
+		args = append(args, "-out="+opts.Out)
+	}
+	if opts.RefreshOnly {
+		args = append(args, "-refresh-only")
+	}
+	args = appendTargets(args, opts.Targets)
+
+	err := t.run(ctx, args...)
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 2 {
+		return true, nil
+	}
+	return false, err
+}
+
+// Apply runs 'terraform apply'
+func (t *Terraform) Apply(ctx context.Context, opts ApplyOptions) error {
+	args := []string{"apply"}
+	if opts.AutoApprove {
+		args = append(args, "-auto-approve")
+	}
+	args = appendTargets(args, opts.Targets)
+	if opts.PlanFile != "" {
+		args = append(args, opts.PlanFile)
+	}
+	return t.run(ctx, args...)
+}
+
+// Destroy runs 'terraform destroy'
+func (t *Terraform) Destroy(ctx context.Context, opts DestroyOptions) error {
+	args := []string{"destroy"}
+	if opts.AutoApprove {
+		args = append(args, "-auto-approve")
+	}
+	return t.run(ctx, appendTargets(args, opts.Targets)...)
+}
+
+// Output runs 'terraform output -json'
+func (t *Terraform) Output(ctx context.Context) (map[string]Output, error) {
+	data, err := t.capture(ctx, "output", "-json")
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := map[string]Output{}
+	if err := json.Unmarshal(data, &outputs); err != nil {
+		return nil, fmt.Errorf("failed to parse terraform output: %w", err)
+	}
+	return outputs, nil
+}
+
+// Show runs 'terraform show -json'
+func (t *Terraform) Show(ctx context.Context, planFile string) ([]byte, error) {
+	args := []string{"show", "-json"}
+	if planFile != "" {
+		args = append(args, planFile)
+	}
+	return t.capture(ctx, args...)
+}
+
+func (t *Terraform) command(ctx context.Context, args ...string) (*exec.Cmd, error) {
+	binary := t.Binary
+	if binary == "" {
+		binary = "terraform"
+	}
+	path, err := exec.LookPath(binary)
+	if err != nil {
+		return nil, fmt.Errorf("terraform not found: install it from https://developer.hashicorp.com/terraform/install")
+	}
+
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Dir = t.Dir
+	cmd.Env = t.Env
+	cmd.Stdin = t.Stdin
+	cmd.Stdout = t.Stdout
+	cmd.Stderr = t.Stderr
+	if cmd.Stdout == nil {
+		cmd.Stdout = os.Stdout
+	}
+	if cmd.Stderr == nil {
+		cmd.Stderr = os.Stderr
+	}
+	return cmd, nil
+}
+
+func (t *Terraform) run(ctx context.Context, args ...string) error {
+	cmd, err := t.command(ctx, args...)
+	if err != nil {
+		return err
+	}
+	return cmd.Run()
+}
+
+// capture runs a command and returns its standard output
+func (t *Terraform) capture(ctx context.Context, args ...string) ([]byte, error) {
+	cmd, err := t.command(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	if err := cmd.Run(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+func appendTargets(args, targets []string) []string {
+	for _, target := range targets {
+		args = append(args, "-target="+target)
+	}
+	return args
+}
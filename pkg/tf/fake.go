@@ -0,0 +1,120 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tf
+
+import (
+	"context"
+	"os"
+	"sync"
+)
+
+// Call records one invocation of a Fake
+type Call struct {
+	Method  string      // init, validate, plan, apply, destroy, output or show
+	Dir     string      // working directory the runner was created for
+	Options interface{} // PlanOptions, ApplyOptions, DestroyOptions or the show plan file
+}
+
+// Fake is an in-memory Runner for tests. It records every call and returns
+// the canned results configured on it.
+type Fake struct {
+	Changes  bool              // returned by Plan
+	Outputs  map[string]Output // returned by Output
+	ShowJSON []byte            // returned by Show; defaults to an empty plan
+	Errors   map[string]error  // per-method errors, keyed like Call.Method
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+// Runner returns a Runner bound to opts that records into f, for use as a
+// runner factory
+func (f *Fake) Runner(opts Options) Runner {
+	return &fakeRunner{fake: f, dir: opts.Dir}
+}
+
+// Calls returns the recorded calls in order
+func (f *Fake) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Call(nil), f.calls...)
+}
+
+// Methods returns the names of the recorded calls in order
+func (f *Fake) Methods() []string {
+	var methods []string
+	for _, call := range f.Calls() {
+		methods = append(methods, call.Method)
+	}
+	return methods
+}
+
+func (f *Fake) record(method, dir string, opts interface{}) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, Call{Method: method, Dir: dir, Options: opts})
+	return f.Errors[method]
+}
+
+type fakeRunner struct {
+	fake *Fake
+	dir  string
+}
+
+func (r *fakeRunner) Init(ctx context.Context) error {
+	return r.fake.record("init", r.dir, nil)
+}
+
+func (r *fakeRunner) Validate(ctx context.Context) error {
+	return r.fake.record("validate", r.dir, nil)
+}
+
+func (r *fakeRunner) Plan(ctx context.Context, opts PlanOptions) (bool, error) {
+	if err := r.fake.record("plan", r.dir, opts); err != nil {
+		return false, err
+	}
+	// Write a placeholder so saved-plan flows find the file
+	if opts.Out != "" {
+		if err := os.WriteFile(opts.Out, []byte("fake plan\n"), 0600); err != nil {
+			return false, err
+		}
+	}
+	return r.fake.Changes, nil
+}
+
+func (r *fakeRunner) Apply(ctx context.Context, opts ApplyOptions) error {
+	return r.fake.record("apply", r.dir, opts)
+}
+
+func (r *fakeRunner) Destroy(ctx context.Context, opts DestroyOptions) error {
+	return r.fake.record("destroy", r.dir, opts)
+}
+
+func (r *fakeRunner) Output(ctx context.Context) (map[string]Output, error) {
+	if err := r.fake.record("output", r.dir, nil); err != nil {
+		return nil, err
+	}
+	return r.fake.Outputs, nil
+}
+
+func (r *fakeRunner) Show(ctx context.Context, planFile string) ([]byte, error) {
+	if err := r.fake.record("show", r.dir, planFile); err != nil {
+		return nil, err
+	}
+	if r.fake.ShowJSON == nil {
+		return []byte(`{"format_version":"1.2","resource_changes":[]}`), nil
+	}
+	return r.fake.ShowJSON, nil
+}
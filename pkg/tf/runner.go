@@ -0,0 +1,76 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tf runs Terraform against a generated working directory
+package tf
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+)
+
+// Runner executes Terraform commands in a working directory
+type Runner interface {
+	Init(ctx context.Context) error
+	Validate(ctx context.Context) error
+
+	// Plan reports whether the plan contains changes
+	Plan(ctx context.Context, opts PlanOptions) (bool, error)
+	Apply(ctx context.Context, opts ApplyOptions) error
+	Destroy(ctx context.Context, opts DestroyOptions) error
+
+	// Output returns the root module outputs
+	Output(ctx context.Context) (map[string]Output, error)
+
+	// Show returns the JSON representation of a saved plan, or of the
+	// current state when planFile is empty
+	Show(ctx context.Context, planFile string) ([]byte, error)
+}
+
+// Options configure a Runner
+type Options struct {
+	Dir    string   // working directory
+	Env    []string // process environment; nil inherits the current one
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// PlanOptions configure a plan
+type PlanOptions struct {
+	Out         string // save the plan to this file
+	RefreshOnly bool
+	Targets     []string
+}
+
+// ApplyOptions configure an apply
+type ApplyOptions struct {
+	AutoApprove bool
+	PlanFile    string // apply a saved plan instead of planning again
+	Targets     []string
+}
+
+// DestroyOptions configure a destroy
+type DestroyOptions struct {
+	AutoApprove bool
+	Targets     []string
+}
+
+// Output is a root module output value
+type Output struct {
+	Sensitive bool            `json:"sensitive"`
+	Type      json.RawMessage `json:"type"`
+	Value     json.RawMessage `json:"value"`
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tf
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// StateResource is a resource in the current state
+type StateResource struct {
+	Address       string `json:"address"`
+	ModuleAddress string `json:"-"`
+	Mode          string `json:"mode"`
+	Type          string `json:"type"`
+	Name          string `json:"name"`
+}
+
+type stateModule struct {
+	Address      string          `json:"address"`
+	Resources    []StateResource `json:"resources"`
+	ChildModules []stateModule   `json:"child_modules"`
+}
+
+// StateResources returns the managed resources in the output of
+// 'terraform show -json' without a plan file
+func StateResources(showJSON []byte) ([]StateResource, error) {
+	var state struct {
+		Values *struct {
+			RootModule stateModule `json:"root_module"`
+		} `json:"values"`
+	}
+	if err := json.Unmarshal(showJSON, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse state JSON: %w", err)
+	}
+	if state.Values == nil {
+		return nil, nil // no state yet
+	}
+
+	var resources []StateResource
+	var walk func(m stateModule)
+	walk = func(m stateModule) {
+		for _, r := range m.Resources {
+			if r.Mode != "managed" {
+				continue
+			}
+			r.ModuleAddress = m.Address
+			resources = append(resources, r)
+		}
+		for _, child := range m.ChildModules {
+			walk(child)
+		}
+	}
+	walk(state.Values.RootModule)
+
+	return resources, nil
+}
@@ -0,0 +1,323 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package wizard builds a soloops.yaml manifest from answers collected
+// interactively or from command-line flags
+package wizard
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/OplexTech/soloops-cli/pkg/config"
+	"github.com/OplexTech/soloops-cli/pkg/naming"
+	"gopkg.in/yaml.v3"
+)
+
+// Answers holds everything needed to write a starter manifest
+type Answers struct {
+	Project      string
+	Cloud        string
+	Environments []EnvironmentAnswer
+	Blueprints   []BlueprintSpec
+	RequireHTTPS bool
+	DenyPublicS3 bool
+}
+
+// EnvironmentAnswer describes one environment
+type EnvironmentAnswer struct {
+	Name      string
+	Region    string
+	BudgetUSD float64
+}
+
+// BlueprintSpec selects a blueprint, written as [name=]type[:option] on the
+// command line (e.g. web_api:python3.12 or site=static_site:example.com)
+type BlueprintSpec struct {
+	Name   string
+	Type   string
+	Option string
+}
+
+// Defaults returns the answers used when nothing is specified
+func Defaults() Answers {
+	return Answers{
+		Project: "my-project",
+		Cloud:   "aws",
+		Environments: []EnvironmentAnswer{
+			{Name: "prod", Region: DefaultRegion("aws"), BudgetUSD: 150},
+		},
+		Blueprints: []BlueprintSpec{
+			{Name: config.KindWebAPI, Type: config.KindWebAPI, Option: "node18"},
+			{Name: config.KindStaticSite, Type: config.KindStaticSite, Option: "example.com"},
+		},
+		RequireHTTPS: true,
+		DenyPublicS3: true,
+	}
+}
+
+// DefaultRegion returns a sensible default region for a cloud
+func DefaultRegion(cloud string) string {
+	switch cloud {
+	case "gcp":
+		return "us-central1"
+	case "azure":
+		return "eastus"
+	default:
+		return "us-east-1"
+	}
+}
+
+// DefaultBudget suggests a monthly budget for an environment name
+func DefaultBudget(env string) float64 {
+	switch env {
+	case "prod", "production":
+		return 150
+	case "staging":
+		return 75
+	default:
+		return 50
+	}
+}
+
+// DefaultOption returns the default option for a blueprint type
+func DefaultOption(kind string) string {
+	switch kind {
+	case config.KindWebAPI:
+		return config.SupportedRuntimes[0]
+	case config.KindStaticSite:
+		return "example.com"
+	case config.KindDatabase:
+		return config.SupportedDBTypes[0]
+	}
+	return ""
+}
+
+var (
+	projectPattern = regexp.MustCompile(`^[a-z][a-z0-9-]{1,30}[a-z0-9]$`)
+	envPattern     = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+	domainPattern  = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]*[a-z0-9])?\.)+[a-z]{2,}$`)
+
+	regionPatterns = map[string]*regexp.Regexp{
+		"aws":   regexp.MustCompile(`^[a-z]{2}(-gov)?-[a-z]+-\d$`),
+		"gcp":   regexp.MustCompile(`^[a-z]+-[a-z]+\d$`),
+		"azure": regexp.MustCompile(`^[a-z]+\d?$`),
+	}
+)
+
+// ValidateProject checks a project name (lowercase, digits and hyphens)
+func ValidateProject(name string) error {
+	if !projectPattern.MatchString(name) {
+		return fmt.Errorf("project name must be 3-32 lowercase letters, digits or hyphens, starting with a letter")
+	}
+	return nil
+}
+
+// ValidateCloud checks a cloud provider
+func ValidateCloud(cloud string) error {
+	if !config.Contains(config.SupportedClouds, cloud) {
+		return fmt.Errorf("unsupported cloud provider: %s (supported: %s)", cloud, strings.Join(config.SupportedClouds, ", "))
+	}
+	return nil
+}
+
+// ValidateEnvironmentName checks an environment name
+func ValidateEnvironmentName(name string) error {
+	if !envPattern.MatchString(name) {
+		return fmt.Errorf("invalid environment name %q (use lowercase letters, digits and hyphens)", name)
+	}
+	if config.Contains(config.ReservedEnvironmentNames, name) {
+		return fmt.Errorf("environment name %q is reserved", name)
+	}
+	return nil
+}
+
+// ValidateRegion checks that a region looks right for the cloud
+func ValidateRegion(cloud, region string) error {
+	if pattern, ok := regionPatterns[cloud]; ok && !pattern.MatchString(region) {
+		return fmt.Errorf("%q does not look like a %s region (e.g. %s)", region, cloud, DefaultRegion(cloud))
+	}
+	return nil
+}
+
+// ParseBudget parses and checks a monthly budget
+func ParseBudget(value string) (float64, error) {
+	budget, err := strconv.ParseFloat(strings.TrimPrefix(value, "$"), 64)
+	if err != nil || budget <= 0 {
+		return 0, fmt.Errorf("budget must be a number greater than 0")
+	}
+	return budget, nil
+}
+
+// ParseBlueprintSpec parses [name=]type[:option]
+func ParseBlueprintSpec(value string) (BlueprintSpec, error) {
+	var spec BlueprintSpec
+
+	rest := value
+	if name, typ, ok := strings.Cut(value, "="); ok {
+		spec.Name = name
+		rest = typ
+	}
+	spec.Type, spec.Option, _ = strings.Cut(rest, ":")
+	if spec.Name == "" {
+		spec.Name = spec.Type
+	}
+	if spec.Option == "" {
+		spec.Option = DefaultOption(spec.Type)
+	}
+
+	if err := spec.Validate(); err != nil {
+		return BlueprintSpec{}, fmt.Errorf("invalid blueprint %q: %w", value, err)
+	}
+	return spec, nil
+}
+
+// Validate checks the blueprint type, name and option
+func (s BlueprintSpec) Validate() error {
+	if !config.IsBlueprintKind(s.Type) {
+		return fmt.Errorf("unknown type %q (supported: %s, %s, %s)",
+			s.Type, config.KindWebAPI, config.KindStaticSite, config.KindDatabase)
+	}
+	if err := naming.ValidateKey(s.Name); err != nil {
+		return err
+	}
+	return ValidateOption(s.Type, s.Option)
+}
+
+// ValidateOption checks a blueprint's type-specific option
+func ValidateOption(kind, option string) error {
+	switch kind {
+	case config.KindWebAPI:
+		if !config.Contains(config.SupportedRuntimes, option) {
+			return fmt.Errorf("unsupported runtime %q (supported: %s)", option, strings.Join(config.SupportedRuntimes, ", "))
+		}
+	case config.KindStaticSite:
+		if !domainPattern.MatchString(option) {
+			return fmt.Errorf("%q is not a valid domain name", option)
+		}
+	case config.KindDatabase:
+		if !config.Contains(config.SupportedDBTypes, option) {
+			return fmt.Errorf("unsupported database type %q (supported: %s)", option, strings.Join(config.SupportedDBTypes, ", "))
+		}
+	}
+	return nil
+}
+
+// Validate checks all answers
+func (a Answers) Validate() error {
+	if err := ValidateProject(a.Project); err != nil {
+		return err
+	}
+	if err := ValidateCloud(a.Cloud); err != nil {
+		return err
+	}
+	if len(a.Environments) == 0 {
+		return fmt.Errorf("at least one environment is required")
+	}
+
+	seen := map[string]bool{}
+	for _, env := range a.Environments {
+		if err := ValidateEnvironmentName(env.Name); err != nil {
+			return err
+		}
+		if seen[env.Name] {
+			return fmt.Errorf("duplicate environment: %s", env.Name)
+		}
+		seen[env.Name] = true
+		if err := ValidateRegion(a.Cloud, env.Region); err != nil {
+			return fmt.Errorf("environment %s: %w", env.Name, err)
+		}
+		if env.BudgetUSD <= 0 {
+			return fmt.Errorf("environment %s: budget must be greater than 0", env.Name)
+		}
+	}
+
+	if len(a.Blueprints) == 0 {
+		return fmt.Errorf("at least one blueprint is required")
+	}
+	names := map[string]bool{}
+	for _, bp := range a.Blueprints {
+		if err := bp.Validate(); err != nil {
+			return err
+		}
+		if names[bp.Name] {
+			return fmt.Errorf("duplicate blueprint name: %s (use name=type:option)", bp.Name)
+		}
+		names[bp.Name] = true
+	}
+
+	return nil
+}
+
+// Manifest converts the answers into a configuration. Every environment gets
+// the same set of blueprints.
+func (a Answers) Manifest() *config.Config {
+	cfg := &config.Config{
+		APIVersion: config.CurrentAPIVersion,
+		Project:    a.Project,
+		Cloud:      a.Cloud,
+		Policies: &config.Policies{
+			RequireHTTPS: a.RequireHTTPS,
+			DenyPublicS3: a.DenyPublicS3,
+		},
+		// New projects have no buckets to rename
+		Naming: &config.Naming{AccountSuffix: true},
+	}
+
+	for _, env := range a.Environments {
+		blueprints := make(map[string]config.Blueprint, len(a.Blueprints))
+		for _, spec := range a.Blueprints {
+			bp := config.Blueprint{Type: spec.Type}
+			switch spec.Type {
+			case config.KindWebAPI:
+				bp.Runtime = spec.Option
+				bp.Ingress = "edge"
+			case config.KindStaticSite:
+				bp.Domain = spec.Option
+			case config.KindDatabase:
+				bp.DBType = spec.Option
+			}
+			blueprints[spec.Name] = bp
+		}
+
+		cfg.Environments = append(cfg.Environments, config.Environment{
+			Name:       env.Name,
+			Region:     env.Region,
+			BudgetUSD:  env.BudgetUSD,
+			Blueprints: blueprints,
+		})
+	}
+
+	return cfg
+}
+
+// Render writes the answers as soloops.yaml content
+func (a Answers) Render() ([]byte, error) {
+	var out bytes.Buffer
+	out.WriteString("# Generated by 'soloops init'\n")
+
+	enc := yaml.NewEncoder(&out)
+	enc.SetIndent(2)
+	if err := enc.Encode(a.Manifest()); err != nil {
+		return nil, fmt.Errorf("failed to render manifest: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to render manifest: %w", err)
+	}
+
+	return out.Bytes(), nil
+}
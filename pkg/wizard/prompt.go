@@ -0,0 +1,231 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wizard
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/OplexTech/soloops-cli/pkg/config"
+)
+
+// Prompter asks questions on a line-oriented terminal
+type Prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// NewPrompter creates a Prompter reading answers from in
+func NewPrompter(in io.Reader, out io.Writer) *Prompter {
+	return &Prompter{in: bufio.NewReader(in), out: out}
+}
+
+// Ask prints a question and returns the answer, or def when the answer is
+// empty. Answers are re-asked until validate accepts them.
+func (p *Prompter) Ask(question, def string, validate func(string) error) (string, error) {
+	for {
+		if def != "" {
+			fmt.Fprintf(p.out, "%s [%s]: ", question, def)
+		} else {
+			fmt.Fprintf(p.out, "%s: ", question)
+		}
+
+		line, err := p.in.ReadString('\n')
+		if err != nil && (err != io.EOF || line == "") {
+			return "", fmt.Errorf("failed to read input: %w", err)
+		}
+
+		answer := strings.TrimSpace(line)
+		if answer == "" {
+			answer = def
+		}
+
+		if validate != nil {
+			if err := validate(answer); err != nil {
+				fmt.Fprintf(p.out, "  ✗ %v\n", err)
+				continue
+			}
+		}
+		return answer, nil
+	}
+}
+
+// Confirm asks a yes/no question
+func (p *Prompter) Confirm(question string, def bool) (bool, error) {
+	hint := "y/N"
+	if def {
+		hint = "Y/n"
+	}
+
+	answer, err := p.Ask(fmt.Sprintf("%s (%s)", question, hint), "", func(s string) error {
+		switch strings.ToLower(s) {
+		case "", "y", "yes", "n", "no":
+			return nil
+		}
+		return fmt.Errorf("please answer yes or no")
+	})
+	if err != nil {
+		return false, err
+	}
+
+	switch strings.ToLower(answer) {
+	case "y", "yes":
+		return true, nil
+	case "n", "no":
+		return false, nil
+	}
+	return def, nil
+}
+
+// Run walks through the wizard, starting from the given defaults
+func Run(p *Prompter, defaults Answers) (Answers, error) {
+	answers := defaults
+	var err error
+
+	fmt.Fprintln(p.out, "SoloOps project setup")
+	fmt.Fprintln(p.out)
+
+	if answers.Project, err = p.Ask("Project name", defaults.Project, ValidateProject); err != nil {
+		return answers, err
+	}
+
+	cloudQuestion := fmt.Sprintf("Cloud provider (%s)", strings.Join(config.SupportedClouds, ", "))
+	if answers.Cloud, err = p.Ask(cloudQuestion, defaults.Cloud, ValidateCloud); err != nil {
+		return answers, err
+	}
+
+	var envNames []string
+	for _, env := range defaults.Environments {
+		envNames = append(envNames, env.Name)
+	}
+	envList, err := p.Ask("Environments (comma-separated)", strings.Join(envNames, ","), func(s string) error {
+		names := splitList(s)
+		if len(names) == 0 {
+			return fmt.Errorf("at least one environment is required")
+		}
+		seen := map[string]bool{}
+		for _, name := range names {
+			if err := ValidateEnvironmentName(name); err != nil {
+				return err
+			}
+			if seen[name] {
+				return fmt.Errorf("duplicate environment: %s", name)
+			}
+			seen[name] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return answers, err
+	}
+
+	answers.Environments = nil
+	for _, name := range splitList(envList) {
+		env := EnvironmentAnswer{Name: name}
+
+		env.Region, err = p.Ask(fmt.Sprintf("Region for %s", name), DefaultRegion(answers.Cloud), func(s string) error {
+			return ValidateRegion(answers.Cloud, s)
+		})
+		if err != nil {
+			return answers, err
+		}
+
+		budget, err := p.Ask(fmt.Sprintf("Monthly budget for %s (USD)", name), formatBudget(DefaultBudget(name)), func(s string) error {
+			_, err := ParseBudget(s)
+			return err
+		})
+		if err != nil {
+			return answers, err
+		}
+		env.BudgetUSD, _ = ParseBudget(budget)
+
+		answers.Environments = append(answers.Environments, env)
+	}
+
+	var kinds []string
+	for _, bp := range defaults.Blueprints {
+		kinds = append(kinds, bp.Type)
+	}
+	blueprintQuestion := fmt.Sprintf("Blueprints (comma-separated: %s, %s, %s)", config.KindWebAPI, config.KindStaticSite, config.KindDatabase)
+	bpList, err := p.Ask(blueprintQuestion, strings.Join(kinds, ","), func(s string) error {
+		types := splitList(s)
+		if len(types) == 0 {
+			return fmt.Errorf("at least one blueprint is required")
+		}
+		seen := map[string]bool{}
+		for _, kind := range types {
+			if !config.IsBlueprintKind(kind) {
+				return fmt.Errorf("unknown blueprint %q", kind)
+			}
+			if seen[kind] {
+				return fmt.Errorf("duplicate blueprint: %s", kind)
+			}
+			seen[kind] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return answers, err
+	}
+
+	answers.Blueprints = nil
+	for _, kind := range splitList(bpList) {
+		question := optionQuestion(kind)
+		option, err := p.Ask(question, DefaultOption(kind), func(s string) error {
+			return ValidateOption(kind, s)
+		})
+		if err != nil {
+			return answers, err
+		}
+		answers.Blueprints = append(answers.Blueprints, BlueprintSpec{Name: kind, Type: kind, Option: option})
+	}
+
+	if answers.RequireHTTPS, err = p.Confirm("Require HTTPS", defaults.RequireHTTPS); err != nil {
+		return answers, err
+	}
+	if answers.DenyPublicS3, err = p.Confirm("Block public storage buckets", defaults.DenyPublicS3); err != nil {
+		return answers, err
+	}
+
+	return answers, answers.Validate()
+}
+
+func optionQuestion(kind string) string {
+	switch kind {
+	case config.KindWebAPI:
+		return fmt.Sprintf("Runtime for %s (%s)", kind, strings.Join(config.SupportedRuntimes, ", "))
+	case config.KindStaticSite:
+		return fmt.Sprintf("Domain for %s", kind)
+	default:
+		return fmt.Sprintf("Database engine for %s (%s)", kind, strings.Join(config.SupportedDBTypes, ", "))
+	}
+}
+
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func formatBudget(budget float64) string {
+	return strconv.FormatFloat(budget, 'f', -1, 64)
+}
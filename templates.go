@@ -0,0 +1,24 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package soloops bundles the files under infra-templates/ into the binary
+// so project starters work without a checkout of this repository.
+package soloops
+
+import "embed"
+
+// Templates holds the contents of infra-templates/
+//
+//go:embed infra-templates
+var Templates embed.FS
@@ -0,0 +1,221 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/OplexTech/soloops-cli/pkg/cli"
+	"github.com/OplexTech/soloops-cli/pkg/tf"
+)
+
+const allEnvsManifest = `project: shop
+cloud: aws
+environments:
+  - name: dev
+    region: us-east-1
+    budget_usd: 10
+    blueprints:
+      api:
+        type: web_api
+  - name: prod
+    region: eu-west-1
+    budget_usd: 100
+    blueprints:
+      api:
+        type: web_api
+      site:
+        type: static_site
+`
+
+func TestGenerateAllEnvs(t *testing.T) {
+	dir := chdirTemp(t)
+	writeManifest(t, dir, allEnvsManifest)
+
+	out, err := runCLI(t, &tf.Fake{}, "", "generate", "--all-envs")
+	if err != nil {
+		t.Fatalf("generate --all-envs failed: %v\n%s", err, out)
+	}
+	for _, want := range []string{"✓ dev: infra/dev/ (1 blueprints)", "✓ prod: infra/prod/ (2 blueprints)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Summary should contain %q:\n%s", want, out)
+		}
+	}
+	if !strings.Contains(readFile(t, "infra/prod/provider.tf"), "eu-west-1") {
+		t.Error("prod should be generated for its own region")
+	}
+	if strings.Contains(readFile(t, "infra/dev/main.tf"), `module "site"`) {
+		t.Error("dev should only contain its own blueprints")
+	}
+
+	out, err = runCLI(t, &tf.Fake{}, "", "generate", "--all-envs", "--check")
+	if err != nil {
+		t.Fatalf("Expected every environment up to date, got %v:\n%s", err, out)
+	}
+
+	writeManifest(t, dir, strings.Replace(allEnvsManifest, "budget_usd: 100", "budget_usd: 200", 1))
+	out, err = runCLI(t, &tf.Fake{}, "", "generate", "--all-envs", "--check")
+	var exitErr *cli.ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != cli.ExitStale || !strings.Contains(err.Error(), "1 of 2 environments are out of date: prod") {
+		t.Fatalf("Expected prod out of date, got %v:\n%s", err, out)
+	}
+	if !strings.Contains(out, "--- infra/prod/budget.tf") {
+		t.Errorf("Expected a diff of prod's budget:\n%s", out)
+	}
+}
+
+func TestGenerateAllEnvsSharesModules(t *testing.T) {
+	dir := chdirTemp(t)
+	writeManifest(t, dir, allEnvsManifest)
+	if err := os.MkdirAll("overrides/modules/web_api", 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile("overrides/modules/web_api/extra.tf", []byte("# extra\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runCLI(t, &tf.Fake{}, "", "generate", "--all-envs")
+	if err != nil {
+		t.Fatalf("generate --all-envs failed: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "✓ modules: infra/modules/") {
+		t.Errorf("Summary should list the shared modules:\n%s", out)
+	}
+	for _, path := range []string{"infra/modules/web_api/main.tf", "infra/modules/static_site/main.tf", "infra/modules/web_api/extra.tf"} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("Expected shared %s: %v", path, err)
+		}
+	}
+	for _, env := range []string{"dev", "prod"} {
+		if _, err := os.Stat("infra/" + env + "/modules"); !os.IsNotExist(err) {
+			t.Errorf("%s should not get its own copy of the modules", env)
+		}
+		if !strings.Contains(readFile(t, "infra/"+env+"/main.tf"), `source = "../modules/web_api"`) {
+			t.Errorf("%s should use the shared modules", env)
+		}
+	}
+
+	// Modules only used by an environment that no longer needs them go
+	writeManifest(t, dir, strings.Replace(allEnvsManifest, "      site:\n        type: static_site\n", "", 1))
+	if out, err := runCLI(t, &tf.Fake{}, "", "generate", "--all-envs"); err != nil {
+		t.Fatalf("generate --all-envs failed: %v\n%s", err, out)
+	}
+	if _, err := os.Stat("infra/modules/static_site"); !os.IsNotExist(err) {
+		t.Error("Unused shared modules should be removed")
+	}
+	if out, err := runCLI(t, &tf.Fake{}, "", "generate", "--all-envs", "--check"); err != nil {
+		t.Fatalf("Expected everything up to date, got %v:\n%s", err, out)
+	}
+}
+
+func TestGenerateAllEnvsRejectsPatchesDifferingPerEnv(t *testing.T) {
+	dir := chdirTemp(t)
+	writeManifest(t, dir, `project: shop
+cloud: aws
+environments:
+  - name: dev
+    region: us-east-1
+    budget_usd: 10
+    blueprints:
+      api:
+        type: web_api
+        terraform:
+          aws_lambda_function.this:
+            description: dev
+  - name: prod
+    region: eu-west-1
+    budget_usd: 100
+    blueprints:
+      api:
+        type: web_api
+        terraform:
+          aws_lambda_function.this:
+            description: prod
+`)
+
+	out, err := runCLI(t, &tf.Fake{}, "", "generate", "--all-envs")
+	if err == nil || !strings.Contains(out, "blueprint api has different terraform patches in environments dev and prod") {
+		t.Fatalf("Expected the differing patches to be reported, got %v:\n%s", err, out)
+	}
+}
+
+func TestLifecycleCommandsRunInEnvironmentDir(t *testing.T) {
+	dir := chdirTemp(t)
+	writeManifest(t, dir, allEnvsManifest)
+	if out, err := runCLI(t, &tf.Fake{}, "", "generate", "--all-envs"); err != nil {
+		t.Fatalf("generate --all-envs failed: %v\n%s", err, out)
+	}
+
+	for env, want := range map[string]string{"prod": "infra/prod", "": "infra/dev"} {
+		args := []string{"preview"}
+		if env != "" {
+			args = append(args, "--env", env)
+		}
+		fake := &tf.Fake{}
+		if out, err := runCLI(t, fake, "", args...); err != nil {
+			t.Fatalf("preview failed: %v\n%s", err, out)
+		}
+		for _, call := range fake.Calls() {
+			if call.Dir != want {
+				t.Errorf("Expected %s to run in %s, got %q", call.Method, want, call.Dir)
+			}
+		}
+	}
+
+	// The saved plan covers the shared modules
+	fake := &tf.Fake{}
+	if out, err := runCLI(t, fake, "", "preview", "--env", "prod"); err != nil {
+		t.Fatalf("preview failed: %v\n%s", err, out)
+	}
+	if err := os.WriteFile("infra/modules/web_api/extra.tf", []byte("# extra\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := runCLI(t, fake, "", "apply", "--env", "prod", "--plan", "infra/soloops.tfplan", "--auto-approve")
+	if err == nil || !strings.Contains(err.Error(), "changed since the plan was made") {
+		t.Errorf("Expected a change to the shared modules to invalidate the plan, got %v", err)
+	}
+}
+
+func TestGenerateAllEnvsReportsEachFailure(t *testing.T) {
+	dir := chdirTemp(t)
+	writeManifest(t, dir, allEnvsManifest+`      web_site:
+        type: static_site
+      web-site:
+        type: static_site
+`)
+
+	out, err := runCLI(t, &tf.Fake{}, "", "generate", "--all-envs")
+	if err == nil || !strings.Contains(err.Error(), "generation failed for 1 of 2 environments: prod") {
+		t.Fatalf("Expected prod to fail, got %v:\n%s", err, out)
+	}
+	if !strings.Contains(out, "✓ dev: infra/dev/") || !strings.Contains(out, "✗ prod: generation failed: physical name conflicts") {
+		t.Errorf("Expected per-environment results:\n%s", out)
+	}
+	if _, err := os.Stat("infra/dev/main.tf"); err != nil {
+		t.Errorf("dev should still be generated: %v", err)
+	}
+}
+
+func TestGenerateAllEnvsRejectsEnv(t *testing.T) {
+	dir := chdirTemp(t)
+	writeManifest(t, dir, allEnvsManifest)
+
+	if _, err := runCLI(t, &tf.Fake{}, "", "generate", "--all-envs", "--env", "dev"); err == nil {
+		t.Error("Expected --env and --all-envs to be rejected together")
+	}
+}
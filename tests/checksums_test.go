@@ -0,0 +1,206 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/OplexTech/soloops-cli/pkg/cli"
+	"github.com/OplexTech/soloops-cli/pkg/config"
+	"github.com/OplexTech/soloops-cli/pkg/generator"
+	"github.com/OplexTech/soloops-cli/pkg/tf"
+)
+
+const checksumManifest = `project: shop
+cloud: aws
+environments:
+  - name: dev
+    region: us-east-1
+    budget_usd: 10
+    blueprints:
+      api:
+        type: web_api
+`
+
+func TestGenerateRefusesHandEdits(t *testing.T) {
+	dir := chdirTemp(t)
+	writeManifest(t, dir, checksumManifest)
+
+	if out, err := runCLI(t, &tf.Fake{}, "", "generate"); err != nil {
+		t.Fatalf("generate failed: %v\n%s", err, out)
+	}
+	if !strings.Contains(readFile(t, filepath.Join("infra", generator.ChecksumFile)), "  main.tf\n") {
+		t.Error("Expected checksums of the generated files")
+	}
+
+	edited := strings.Replace(readFile(t, "infra/main.tf"), "# Generated by SoloOps", "# Generated by SoloOps\n# hand edit", 1)
+	if err := os.WriteFile("infra/main.tf", []byte(edited), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runCLI(t, &tf.Fake{}, "", "generate")
+	if err == nil || !strings.Contains(err.Error(), "edited by hand since the last generate: infra/main.tf") {
+		t.Fatalf("Expected hand edit error, got %v", err)
+	}
+	if !strings.Contains(out, "--- infra/main.tf\n+++ infra/main.tf (generated)") || !strings.Contains(out, "-# hand edit") {
+		t.Errorf("Expected a diff of the hand edit:\n%s", out)
+	}
+	if readFile(t, "infra/main.tf") != edited {
+		t.Error("Edited file should not be overwritten")
+	}
+
+	out, err = runCLI(t, &tf.Fake{}, "", "generate", "--force")
+	if err != nil {
+		t.Fatalf("generate --force failed: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "Overwriting 1 generated files edited by hand") {
+		t.Errorf("Expected an overwrite warning:\n%s", out)
+	}
+	if strings.Contains(readFile(t, "infra/main.tf"), "hand edit") {
+		t.Error("--force should overwrite the edited file")
+	}
+
+	// Regenerating unedited files needs no --force
+	if out, err := runCLI(t, &tf.Fake{}, "", "generate"); err != nil {
+		t.Fatalf("generate failed: %v\n%s", err, out)
+	}
+}
+
+func TestGenerateCheck(t *testing.T) {
+	dir := chdirTemp(t)
+	writeManifest(t, dir, checksumManifest)
+
+	if out, err := runCLI(t, &tf.Fake{}, "", "generate"); err != nil {
+		t.Fatalf("generate failed: %v\n%s", err, out)
+	}
+	out, err := runCLI(t, &tf.Fake{}, "", "generate", "--check")
+	if err != nil || !strings.Contains(out, "infra/ is up to date") {
+		t.Fatalf("Expected up to date, got %v:\n%s", err, out)
+	}
+
+	writeManifest(t, dir, strings.Replace(checksumManifest, "budget_usd: 10", "budget_usd: 25", 1))
+	before := readFile(t, "infra/budget.tf")
+	out, err = runCLI(t, &tf.Fake{}, "", "generate", "--check")
+
+	var exitErr *cli.ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != cli.ExitStale {
+		t.Fatalf("Expected ExitStale, got %v", err)
+	}
+	if !strings.Contains(out, "--- infra/budget.tf") || !strings.Contains(out, "+  limit_amount = \"25.00\"") {
+		t.Errorf("Expected a diff of budget.tf:\n%s", out)
+	}
+	if readFile(t, "infra/budget.tf") != before {
+		t.Error("--check should not write files")
+	}
+
+	// The result is one line on stderr, without usage or an "Error:" prefix
+	stdout, stderr, _ := runCLISplit(t, &tf.Fake{}, "generate", "--check")
+	if strings.Count(stderr, "\n") != 1 || !strings.HasSuffix(stderr, "generated files are out of date; run 'soloops generate'\n") {
+		t.Errorf("Unexpected stderr: %q", stderr)
+	}
+	if strings.Contains(stdout+stderr, "Usage:") {
+		t.Errorf("Expected no usage text:\n%s", stdout)
+	}
+}
+
+func TestGenerateRemovesStaleFiles(t *testing.T) {
+	dir := chdirTemp(t)
+	writeManifest(t, dir, checksumManifest+`      orders:
+        type: database
+        db_type: dynamodb
+`)
+	if out, err := runCLI(t, &tf.Fake{}, "", "generate"); err != nil {
+		t.Fatalf("generate failed: %v\n%s", err, out)
+	}
+	if _, err := os.Stat("infra/modules/dynamodb/main.tf"); err != nil {
+		t.Fatalf("Expected dynamodb module: %v", err)
+	}
+
+	writeManifest(t, dir, checksumManifest)
+	if out, err := runCLI(t, &tf.Fake{}, "", "generate"); err != nil {
+		t.Fatalf("generate failed: %v\n%s", err, out)
+	}
+	if _, err := os.Stat("infra/modules/dynamodb"); !os.IsNotExist(err) {
+		t.Error("Modules no longer used should be removed")
+	}
+	if _, err := os.Stat("infra/modules/web_api/main.tf"); err != nil {
+		t.Errorf("Modules still used should be kept: %v", err)
+	}
+}
+
+func TestGenerateDryRun(t *testing.T) {
+	dir := chdirTemp(t)
+	writeManifest(t, dir, checksumManifest)
+
+	out, err := runCLI(t, &tf.Fake{}, "", "generate", "--dry-run")
+	var exitErr *cli.ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != cli.ExitStale {
+		t.Fatalf("Expected ExitStale, got %v", err)
+	}
+	if !strings.Contains(out, "+++ infra/main.tf (generated)") || !strings.Contains(out, "+module \"api\" {") {
+		t.Errorf("Expected a diff creating main.tf:\n%s", out)
+	}
+	if _, err := os.Stat("infra"); !os.IsNotExist(err) {
+		t.Fatal("--dry-run should not write infra/")
+	}
+
+	if out, err := runCLI(t, &tf.Fake{}, "", "generate"); err != nil {
+		t.Fatalf("generate failed: %v\n%s", err, out)
+	}
+	out, err = runCLI(t, &tf.Fake{}, "", "generate", "--dry-run")
+	if err != nil || !strings.Contains(out, "infra/ is up to date") {
+		t.Fatalf("Expected no changes, got %v:\n%s", err, out)
+	}
+
+	if err := os.WriteFile("infra/outputs.tf", []byte("# hand edit\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	out, err = runCLI(t, &tf.Fake{}, "", "generate", "--dry-run")
+	if err == nil || !strings.Contains(out, "# infra/outputs.tf was edited by hand") || !strings.Contains(out, "-# hand edit") {
+		t.Errorf("Expected the hand edit to be flagged, got %v:\n%s", err, out)
+	}
+}
+
+func TestGeneratorWritesThroughFS(t *testing.T) {
+	chdirTemp(t)
+
+	cfg := &config.Config{Project: "shop", Cloud: "aws"}
+	env := &config.Environment{
+		Name:       "dev",
+		Region:     "us-east-1",
+		Blueprints: map[string]config.Blueprint{"api": {Type: config.KindWebAPI}},
+	}
+	gen := generator.New(cfg, env)
+	overlay := generator.NewOverlay(generator.OS)
+	gen.FS = overlay
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if _, err := os.Stat("infra"); !os.IsNotExist(err) {
+		t.Error("Generating into an overlay should not touch the disk")
+	}
+	main, err := overlay.ReadFile("infra/main.tf")
+	if err != nil || !strings.Contains(string(main), `module "api"`) {
+		t.Errorf("Expected main.tf in the overlay, got %v", err)
+	}
+	if paths := strings.Join(overlay.Paths(), ","); !strings.Contains(paths, "infra/modules/web_api/main.tf") {
+		t.Errorf("Unexpected paths: %s", paths)
+	}
+}
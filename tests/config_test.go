@@ -17,6 +17,7 @@ package tests
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/OplexTech/soloops-cli/pkg/config"
@@ -163,6 +164,30 @@ func TestConfigValidate(t *testing.T) {
 	}
 }
 
+func TestConfigValidatesEnvironmentNames(t *testing.T) {
+	tests := []struct {
+		names   []string
+		wantErr string
+	}{
+		{[]string{"dev", "modules"}, `environment[1]: "modules" is reserved`},
+		{[]string{"../../outside"}, `environment[0]: invalid name "../../outside"`},
+		{[]string{"prod/eu"}, `environment[0]: invalid name "prod/eu"`},
+		{[]string{"dev", "prod", "dev"}, `environment[2]: duplicate environment "dev"`},
+	}
+	for _, tt := range tests {
+		cfg := &config.Config{Project: "shop", Cloud: "aws"}
+		for _, name := range tt.names {
+			cfg.Environments = append(cfg.Environments, config.Environment{
+				Name: name, Region: "us-east-1", BudgetUSD: 10,
+				Blueprints: map[string]config.Blueprint{"api": {Type: config.KindWebAPI}},
+			})
+		}
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%v: expected error containing %q, got %v", tt.names, tt.wantErr, err)
+		}
+	}
+}
+
 func TestGetEnvironment(t *testing.T) {
 	cfg := &config.Config{
 		Project: "test",
@@ -188,3 +213,46 @@ func TestGetEnvironment(t *testing.T) {
 		t.Error("Expected error for non-existent environment")
 	}
 }
+
+func TestReleaseValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		bp      config.Blueprint
+		wantErr string
+	}{
+		{"canary", config.Blueprint{Type: "web_api", Release: &config.Release{Strategy: "canary", Steps: []int{10, 50, 100}, Interval: "5m"}}, ""},
+		{"blue green", config.Blueprint{Type: "web_api", Release: &config.Release{Strategy: "blue_green"}}, ""},
+		{"unknown strategy", config.Blueprint{Type: "web_api", Release: &config.Release{Strategy: "yolo"}}, "unknown strategy"},
+		{"steps not ending at 100", config.Blueprint{Type: "web_api", Release: &config.Release{Strategy: "canary", Steps: []int{10, 50}}}, "must end at 100"},
+		{"decreasing steps", config.Blueprint{Type: "web_api", Release: &config.Release{Strategy: "canary", Steps: []int{50, 10, 100}}}, "must increase"},
+		{"bad interval", config.Blueprint{Type: "web_api", Release: &config.Release{Strategy: "canary", Interval: "soon"}}, "positive duration"},
+		{"static site", config.Blueprint{Type: "static_site", Release: &config.Release{Strategy: "canary"}}, "only supported for web_api"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Project: "shop",
+				Cloud:   "aws",
+				Environments: []config.Environment{
+					{Name: "dev", Region: "us-east-1", BudgetUSD: 50, Blueprints: map[string]config.Blueprint{"api": tt.bp}},
+				},
+			}
+			err := cfg.Validate()
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("Expected no error but got: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	canary := &config.Release{Strategy: "canary"}
+	if got := canary.TrafficSteps(); len(got) != 2 || got[0] != 10 || got[1] != 100 {
+		t.Errorf("Expected default canary steps [10 100], got %v", got)
+	}
+	if canary.IntervalDuration() != config.DefaultReleaseInterval {
+		t.Errorf("Expected the default interval, got %s", canary.IntervalDuration())
+	}
+}
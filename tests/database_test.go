@@ -0,0 +1,150 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/OplexTech/soloops-cli/pkg/config"
+	"github.com/OplexTech/soloops-cli/pkg/generator"
+)
+
+func TestGenerateDatabases(t *testing.T) {
+	chdirTemp(t)
+
+	cfg := &config.Config{Project: "shop", Cloud: "aws"}
+	env := &config.Environment{
+		Name:   "dev",
+		Region: "us-east-1",
+		Blueprints: map[string]config.Blueprint{
+			"orders":   {Type: config.KindDatabase, DBType: "postgres"},
+			"reports":  {Type: config.KindDatabase, DBType: "mysql"},
+			"ledger":   {Type: config.KindDatabase, DBType: "aurora_serverless_v2"},
+			"sessions": {Type: config.KindDatabase, DBType: "dynamodb"},
+			"legacy":   {Type: config.KindDatabase, DBType: "cassandra"},
+		},
+	}
+	if err := generator.New(cfg, env).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	main := readFile(t, "infra/main.tf")
+	for _, want := range []string{
+		`source = "./modules/rds"`,
+		`engine     = "postgres"`,
+		`engine     = "mysql"`,
+		`source = "./modules/aurora_serverless_v2"`,
+		`instance_identifier = "shop-dev-ledger-1"`,
+		`source = "./modules/dynamodb"`,
+		`table_name = "shop-dev-sessions"`,
+		`# Unsupported db_type "cassandra"`,
+	} {
+		if !strings.Contains(main, want) {
+			t.Errorf("main.tf should contain %q:\n%s", want, main)
+		}
+	}
+
+	for module, wants := range map[string][]string{
+		"rds": {
+			`resource "aws_db_instance" "this"`,
+			"manage_master_user_password = true",
+			"storage_encrypted           = true",
+			"skip_final_snapshot         = true",
+			`resource "aws_security_group" "this"`,
+		},
+		"aurora_serverless_v2": {
+			`engine                      = "aurora-postgresql"`,
+			`instance_class     = "db.serverless"`,
+			"serverlessv2_scaling_configuration",
+		},
+		"dynamodb": {
+			`billing_mode                = "PAY_PER_REQUEST"`,
+			"point_in_time_recovery",
+		},
+	} {
+		content := readFile(t, "infra/modules/"+module+"/main.tf")
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s module should contain %q:\n%s", module, want, content)
+			}
+		}
+	}
+
+	outputs := readFile(t, "infra/outputs.tf")
+	for _, want := range []string{
+		`output "orders_db_endpoint"`,
+		`output "ledger_db_endpoint"`,
+		`output "sessions_table_name"`,
+	} {
+		if !strings.Contains(outputs, want) {
+			t.Errorf("outputs.tf should contain %q:\n%s", want, outputs)
+		}
+	}
+}
+
+func TestGenerateProtectedDatabaseInputs(t *testing.T) {
+	chdirTemp(t)
+
+	cfg := &config.Config{Project: "shop", Cloud: "aws"}
+	env := &config.Environment{
+		Name:   "dev",
+		Region: "us-east-1",
+		Blueprints: map[string]config.Blueprint{
+			"orders":   {Type: config.KindDatabase, DBType: "postgres", Protect: true},
+			"ledger":   {Type: config.KindDatabase, DBType: "aurora_serverless_v2", Protect: true},
+			"sessions": {Type: config.KindDatabase, DBType: "dynamodb", Protect: true},
+		},
+	}
+	if err := generator.New(cfg, env).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	main := readFile(t, "infra/main.tf")
+	for name, module := range map[string]string{
+		"orders":   "rds_protected",
+		"ledger":   "aurora_serverless_v2_protected",
+		"sessions": "dynamodb_protected",
+	} {
+		variables := readFile(t, "infra/modules/"+module+"/variables.tf")
+		for _, input := range moduleInputs(t, main, name) {
+			if input != "source" && !strings.Contains(variables, `variable "`+input+`"`) {
+				t.Errorf("module %s sets %s, which %s does not declare", name, input, module)
+			}
+		}
+	}
+	if strings.Contains(main, "shop-dev-sessions-final") {
+		t.Error("DynamoDB tables have no final snapshot")
+	}
+}
+
+// moduleInputs returns the arguments set in a module block of main.tf
+func moduleInputs(t *testing.T, main, name string) []string {
+	t.Helper()
+	start := strings.Index(main, `module "`+name+`" {`)
+	if start < 0 {
+		t.Fatalf("main.tf has no module %s:\n%s", name, main)
+	}
+	block := main[start:]
+	block = block[:strings.Index(block, "\n}")]
+
+	var inputs []string
+	for _, line := range strings.Split(block, "\n")[1:] {
+		if key, _, ok := strings.Cut(strings.TrimSpace(line), " = "); ok {
+			inputs = append(inputs, strings.TrimSpace(key))
+		}
+	}
+	return inputs
+}
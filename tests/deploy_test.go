@@ -0,0 +1,661 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/OplexTech/soloops-cli/pkg/deploy"
+	"github.com/OplexTech/soloops-cli/pkg/tf"
+	"github.com/aws/smithy-go/encoding/cbor"
+)
+
+type storedObject struct {
+	body         []byte
+	contentType  string
+	cacheControl string
+}
+
+// fakeAWS is an in-memory stand-in for the S3 and CloudFront APIs
+type fakeAWS struct {
+	mu            sync.Mutex
+	objects       map[string]storedObject
+	puts          []string
+	deletes       []string
+	invalidations []string
+	authFailures  int
+}
+
+func newFakeAWS(t *testing.T) *fakeAWS {
+	t.Helper()
+	f := &fakeAWS{objects: map[string]storedObject{}}
+	server := httptest.NewServer(f)
+	t.Cleanup(server.Close)
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDTEST")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_SESSION_TOKEN", "")
+	t.Setenv("AWS_ENDPOINT_URL_S3", server.URL)
+	t.Setenv("AWS_ENDPOINT_URL_CLOUDFRONT", server.URL)
+	return f
+}
+
+func (f *fakeAWS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKIDTEST/") {
+		f.authFailures++
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `<Error><Code>AccessDenied</Code><Message>unsigned</Message></Error>`)
+		return
+	}
+
+	if strings.HasPrefix(r.URL.Path, "/2020-05-31/distribution/") {
+		parts := strings.Split(r.URL.Path, "/")
+		f.invalidations = append(f.invalidations, parts[3])
+		fmt.Fprint(w, `<Invalidation><Id>I123</Id><Status>InProgress</Status></Invalidation>`)
+		return
+	}
+
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if bucket != "shop-dev-site" {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `<Error><Code>NoSuchBucket</Code><Message>missing</Message></Error>`)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		keys := make([]string, 0, len(f.objects))
+		for k := range f.objects {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var out strings.Builder
+		out.WriteString(`<ListBucketResult>`)
+		for _, k := range keys {
+			sum := md5.Sum(f.objects[k].body)
+			fmt.Fprintf(&out, `<Contents><Key>%s</Key><ETag>"%s"</ETag><Size>%d</Size></Contents>`, k, hex.EncodeToString(sum[:]), len(f.objects[k].body))
+		}
+		out.WriteString(`<IsTruncated>false</IsTruncated></ListBucketResult>`)
+		fmt.Fprint(w, out.String())
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		f.objects[key] = storedObject{body: body, contentType: r.Header.Get("Content-Type"), cacheControl: r.Header.Get("Cache-Control")}
+		f.puts = append(f.puts, key)
+	case http.MethodDelete:
+		delete(f.objects, key)
+		f.deletes = append(f.deletes, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+const siteConfig = `apiVersion: soloops/v1
+project: shop
+cloud: aws
+environments:
+  - name: dev
+    region: us-east-1
+    budget_usd: 50
+    blueprints:
+      site:
+        type: static_site
+`
+
+func siteOutputs() *tf.Fake {
+	str := func(s string) tf.Output { return tf.Output{Value: json.RawMessage(`"` + s + `"`)} }
+	return &tf.Fake{Outputs: map[string]tf.Output{
+		"site_bucket_name":                str("shop-dev-site"),
+		"site_cloudfront_url":             str("d111.cloudfront.net"),
+		"site_cloudfront_distribution_id": str("E2EXAMPLE"),
+	}}
+}
+
+// writeSite writes files to ./site, which deploy uses without --dir
+func writeSite(t *testing.T, files map[string]string) {
+	t.Helper()
+	writeSiteDir(t, "site", files)
+}
+
+func writeSiteDir(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestDeployStaticSite(t *testing.T) {
+	dir := chdirTemp(t)
+	writeManifest(t, dir, siteConfig)
+	if err := os.MkdirAll("infra", 0755); err != nil {
+		t.Fatal(err)
+	}
+	s3 := newFakeAWS(t)
+	s3.objects["old.html"] = storedObject{body: []byte("stale")}
+	writeSiteDir(t, "dist", map[string]string{
+		"index.html":        "<h1>hi</h1>",
+		"assets/app.js":     "console.log(1)",
+		"assets/style.css":  "body{}",
+		".DS_Store":         "junk",
+		"img/logo file.svg": "<svg/>",
+	})
+
+	out, err := runCLI(t, siteOutputs(), "", "deploy", "site", "--dir", "dist")
+	if err != nil {
+		t.Fatalf("deploy failed: %v\n%s", err, out)
+	}
+
+	if s3.authFailures > 0 {
+		t.Errorf("Expected every request to be signed, got %d unsigned", s3.authFailures)
+	}
+	if len(s3.puts) != 4 {
+		t.Errorf("Expected 4 uploads, got %v", s3.puts)
+	}
+	if len(s3.deletes) != 1 || s3.deletes[0] != "old.html" {
+		t.Errorf("Expected old.html to be deleted, got %v", s3.deletes)
+	}
+	if _, ok := s3.objects[".DS_Store"]; ok {
+		t.Error("Hidden files should not be uploaded")
+	}
+
+	for key, want := range map[string][2]string{
+		"index.html":        {"text/html; charset=utf-8", "public, max-age=300"},
+		"assets/app.js":     {"text/javascript; charset=utf-8", "public, max-age=31536000"},
+		"assets/style.css":  {"text/css; charset=utf-8", "public, max-age=31536000"},
+		"img/logo file.svg": {"image/svg+xml", "public, max-age=31536000"},
+	} {
+		obj, ok := s3.objects[key]
+		if !ok {
+			t.Errorf("Expected %s to be uploaded", key)
+			continue
+		}
+		if obj.contentType != want[0] || obj.cacheControl != want[1] {
+			t.Errorf("%s: got Content-Type %q, Cache-Control %q; want %q, %q", key, obj.contentType, obj.cacheControl, want[0], want[1])
+		}
+	}
+
+	if len(s3.invalidations) != 1 || s3.invalidations[0] != "E2EXAMPLE" {
+		t.Errorf("Expected one invalidation of E2EXAMPLE, got %v", s3.invalidations)
+	}
+	if !strings.Contains(out, "Invalidated CloudFront cache (I123)") || !strings.Contains(out, "https://d111.cloudfront.net") {
+		t.Errorf("Unexpected output:\n%s", out)
+	}
+}
+
+func TestDeployStaticSiteUploadsOnlyChanges(t *testing.T) {
+	dir := chdirTemp(t)
+	writeManifest(t, dir, siteConfig)
+	if err := os.MkdirAll("infra", 0755); err != nil {
+		t.Fatal(err)
+	}
+	s3 := newFakeAWS(t)
+	writeSite(t, map[string]string{"index.html": "v1", "app.js": "js"})
+
+	if _, err := runCLI(t, siteOutputs(), "", "deploy", "site"); err != nil {
+		t.Fatalf("first deploy failed: %v", err)
+	}
+
+	s3.puts, s3.invalidations = nil, nil
+	out, err := runCLI(t, siteOutputs(), "", "deploy", "site")
+	if err != nil {
+		t.Fatalf("second deploy failed: %v", err)
+	}
+	if len(s3.puts) != 0 || len(s3.invalidations) != 0 || !strings.Contains(out, "Already up to date") {
+		t.Errorf("Expected nothing to change, got puts %v, invalidations %v\n%s", s3.puts, s3.invalidations, out)
+	}
+
+	writeSite(t, map[string]string{"index.html": "v2"})
+	if _, err := runCLI(t, siteOutputs(), "", "deploy", "site"); err != nil {
+		t.Fatalf("third deploy failed: %v", err)
+	}
+	if len(s3.puts) != 1 || s3.puts[0] != "index.html" {
+		t.Errorf("Expected only index.html to be uploaded, got %v", s3.puts)
+	}
+}
+
+func TestDeployDryRun(t *testing.T) {
+	dir := chdirTemp(t)
+	writeManifest(t, dir, siteConfig)
+	if err := os.MkdirAll("infra", 0755); err != nil {
+		t.Fatal(err)
+	}
+	s3 := newFakeAWS(t)
+	s3.objects["old.txt"] = storedObject{body: []byte("x")}
+	writeSite(t, map[string]string{"index.html": "v1"})
+
+	out, err := runCLI(t, siteOutputs(), "", "deploy", "site", "--dry-run")
+	if err != nil {
+		t.Fatalf("deploy failed: %v", err)
+	}
+	if len(s3.puts) != 0 || len(s3.deletes) != 0 || len(s3.invalidations) != 0 {
+		t.Errorf("Dry run changed the bucket: puts %v, deletes %v", s3.puts, s3.deletes)
+	}
+	for _, want := range []string{"  + index.html", "  - old.txt", "Dry run"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q\n%s", want, out)
+		}
+	}
+}
+
+func TestDeployRejectsUnsupportedBlueprint(t *testing.T) {
+	generateProtectedProject(t)
+
+	_, err := runCLI(t, &tf.Fake{}, "", "deploy", "db")
+	if err == nil || !strings.Contains(err.Error(), "deploy supports web_api and static_site") {
+		t.Errorf("Expected an unsupported type error, got %v", err)
+	}
+}
+
+// fakeLambda is an in-memory stand-in for the Lambda code, version and alias
+// APIs
+type fakeLambda struct {
+	mu       sync.Mutex
+	url      string
+	code     map[string][]byte // by version, "$LATEST" included
+	alias    string
+	versions int
+	routes   []string // alias updates as "<version> +<version>@<weight>"
+	requests []string
+	health   int      // status code the handler answers health checks with
+	checked  []string // versions invoked with a health check
+}
+
+func newFakeLambda(t *testing.T) *fakeLambda {
+	t.Helper()
+	l := &fakeLambda{code: map[string][]byte{"1": []byte("v1"), "$LATEST": []byte("v1")}, alias: "1", versions: 1, health: http.StatusOK}
+	server := httptest.NewServer(l)
+	t.Cleanup(server.Close)
+	l.url = server.URL
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDTEST")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_SESSION_TOKEN", "")
+	t.Setenv("AWS_ENDPOINT_URL_LAMBDA", server.URL)
+	return l
+}
+
+func (l *fakeLambda) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Presigned code downloads are not SigV4-signed
+	if version, ok := strings.CutPrefix(r.URL.Path, "/download/"); ok {
+		w.Write(l.code[version])
+		return
+	}
+	if !strings.Contains(r.Header.Get("Authorization"), "/us-east-1/lambda/aws4_request") {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	rest := strings.TrimPrefix(r.URL.Path, "/2015-03-31/functions/")
+	parts := strings.Split(rest, "/")
+	action := r.Method + " " + strings.Join(parts[1:], "/")
+	l.requests = append(l.requests, action)
+
+	config := func(version string) map[string]string {
+		return map[string]string{"Version": version, "CodeSha256": deploySha(l.code[version]), "State": "Active", "LastUpdateStatus": "Successful"}
+	}
+	qualifier := r.URL.Query().Get("Qualifier")
+
+	switch action {
+	case "GET aliases/live":
+		json.NewEncoder(w).Encode(map[string]string{"Name": "live", "FunctionVersion": l.alias})
+	case "PUT aliases/live":
+		var in struct {
+			FunctionVersion string
+			RoutingConfig   struct{ AdditionalVersionWeights map[string]float64 }
+		}
+		json.NewDecoder(r.Body).Decode(&in)
+		l.alias = in.FunctionVersion
+		route := in.FunctionVersion
+		for version, weight := range in.RoutingConfig.AdditionalVersionWeights {
+			route += fmt.Sprintf(" +%s@%g", version, weight)
+		}
+		l.routes = append(l.routes, route)
+		json.NewEncoder(w).Encode(map[string]string{"Name": "live", "FunctionVersion": l.alias})
+	case "GET configuration":
+		json.NewEncoder(w).Encode(config(qualifier))
+	case "PUT code":
+		var in struct {
+			ZipFile []byte
+			Publish bool
+		}
+		json.NewDecoder(r.Body).Decode(&in)
+		l.code["$LATEST"] = in.ZipFile
+		l.versions++
+		version := fmt.Sprint(l.versions)
+		l.code[version] = in.ZipFile
+		json.NewEncoder(w).Encode(config(version))
+	case "GET ":
+		json.NewEncoder(w).Encode(map[string]interface{}{"Code": map[string]string{"Location": l.url + "/download/" + qualifier}})
+	case "POST invocations":
+		var event struct{ RawPath string }
+		json.NewDecoder(r.Body).Decode(&event)
+		if event.RawPath != "/health" {
+			json.NewEncoder(w).Encode(map[string]int{"statusCode": http.StatusNotFound})
+			return
+		}
+		l.checked = append(l.checked, qualifier)
+		json.NewEncoder(w).Encode(map[string]int{"statusCode": l.health})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"Type":"ResourceNotFoundException","message":"%s"}`, action)
+	}
+}
+
+func deploySha(zip []byte) string {
+	sum := sha256.Sum256(zip)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// apiProject generates the lifecycle project with handler code in api/ and
+// returns a fake whose outputs point at the function
+func apiProject(t *testing.T) *tf.Fake {
+	t.Helper()
+	generateProject(t)
+	if err := os.MkdirAll("api", 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile("api/index.js", []byte("exports.handler = async () => ({statusCode: 200})\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return &tf.Fake{Outputs: map[string]tf.Output{
+		"api_lambda_arn": {Value: json.RawMessage(`"arn:aws:lambda:us-east-1:123456789012:function:shop-dev-api"`)},
+		"api_api_url":    {Value: json.RawMessage(`"https://api.example.com/"`)},
+	}}
+}
+
+func TestDeployWebAPIPublishesAndMovesAlias(t *testing.T) {
+	lambda := newFakeLambda(t)
+	fake := apiProject(t)
+
+	out, err := runCLI(t, fake, "", "deploy", "api")
+	if err != nil {
+		t.Fatalf("deploy failed: %v\n%s", err, out)
+	}
+	if lambda.alias != "2" {
+		t.Errorf("Expected alias live to move to version 2, got %s", lambda.alias)
+	}
+	if len(lambda.checked) == 0 || lambda.checked[0] != "2" {
+		t.Errorf("Expected the health check to invoke version 2 directly, got %v", lambda.checked)
+	}
+	for _, want := range []string{"Published version 2", "Moved alias live from version 1 to 2", "Health check passed", "✓ Version 2 of api is live"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q\n%s", want, out)
+		}
+	}
+
+	// Deploying the same code again publishes nothing
+	lambda.requests = nil
+	out, err = runCLI(t, fake, "", "deploy", "api")
+	if err != nil {
+		t.Fatalf("second deploy failed: %v", err)
+	}
+	if !strings.Contains(out, "Already up to date (version 2 is live)") {
+		t.Errorf("Expected an up to date message:\n%s", out)
+	}
+	for _, req := range lambda.requests {
+		if strings.HasPrefix(req, "PUT") {
+			t.Errorf("Expected no updates, got %v", lambda.requests)
+		}
+	}
+}
+
+func TestDeployWebAPIRollsBackOnFailedHealthCheck(t *testing.T) {
+	lambda := newFakeLambda(t)
+	lambda.health = http.StatusInternalServerError
+	fake := apiProject(t)
+
+	out, err := runCLI(t, fake, "", "deploy", "api", "--health-timeout", "50ms")
+	if err == nil || !strings.Contains(err.Error(), "rolled back to version 1") {
+		t.Fatalf("Expected a rollback error, got %v\n%s", err, out)
+	}
+
+	// The alias serves the previous code, republished as the newest version
+	if lambda.alias != "3" || string(lambda.code["3"]) != "v1" || string(lambda.code["$LATEST"]) != "v1" {
+		t.Errorf("Expected alias on version 3 with the code of version 1, got alias %s, code %q", lambda.alias, lambda.code[lambda.alias])
+	}
+	if !strings.Contains(out, "Moved alias live back to version 1") {
+		t.Errorf("Expected the rollback to be reported:\n%s", out)
+	}
+	// The failing version never took traffic
+	for _, route := range lambda.routes {
+		if strings.Contains(route, "2") {
+			t.Errorf("Expected no traffic on version 2, got alias routes %v", lambda.routes)
+		}
+	}
+}
+
+func TestDeployWebAPIDryRun(t *testing.T) {
+	lambda := newFakeLambda(t)
+	fake := apiProject(t)
+
+	out, err := runCLI(t, fake, "", "deploy", "api", "--dry-run")
+	if err != nil {
+		t.Fatalf("deploy failed: %v", err)
+	}
+	if len(lambda.requests) != 0 || !strings.Contains(out, "Dry run") {
+		t.Errorf("Expected no Lambda calls, got %v\n%s", lambda.requests, out)
+	}
+}
+
+func TestDeployPackageInstallsPythonWheelsForLambda(t *testing.T) {
+	// A stand-in pip records its arguments instead of installing anything
+	bin := t.TempDir()
+	script := "#!/bin/sh\necho \"$@\" > pip-args.txt\n"
+	if err := os.WriteFile(filepath.Join(bin, "pip"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "requirements.txt"), []byte("pydantic==2.9.2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	data, err := deploy.BuildPackage(deploy.BuildOptions{Dir: dir, Runtime: "python3.12", Architecture: "arm64"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var args string
+	for _, f := range zr.File {
+		if f.Name == "pip-args.txt" {
+			rc, _ := f.Open()
+			content, _ := io.ReadAll(rc)
+			rc.Close()
+			args = string(content)
+		}
+	}
+	for _, want := range []string{"--platform manylinux2014_aarch64", "--python-version 3.12", "--only-binary=:all:", "-t ."} {
+		if !strings.Contains(args, want) {
+			t.Errorf("Expected pip to run with %s, got %q", want, args)
+		}
+	}
+	if got := strings.Join(deploy.PipInstallArgs("python3.11", "x86_64"), " "); !strings.Contains(got, "--platform manylinux2014_x86_64 --implementation cp --python-version 3.11") {
+		t.Errorf("Unexpected x86_64 pip arguments: %s", got)
+	}
+}
+
+func TestDeployPackageIsDeterministic(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "index.js"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	first, err := deploy.Zip(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(filepath.Join(dir, "index.js"), later, later); err != nil {
+		t.Fatal(err)
+	}
+	second, err := deploy.Zip(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deploy.CodeSha256(first) != deploy.CodeSha256(second) {
+		t.Error("Expected the same sources to produce the same package")
+	}
+}
+
+const canaryConfig = `apiVersion: soloops/v1
+project: shop
+cloud: aws
+environments:
+  - name: dev
+    region: us-east-1
+    budget_usd: 50
+    blueprints:
+      api:
+        type: web_api
+        runtime: node18
+        release:
+          strategy: canary
+          steps: [10, 50, 100]
+          interval: 20ms
+`
+
+// fakeCloudWatch answers DescribeAlarms, which CloudWatch serves over the
+// Smithy RPC v2 CBOR protocol, with the given alarm state
+func fakeCloudWatch(t *testing.T, state string) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		in, err := cbor.Decode(body)
+		if !strings.HasSuffix(r.URL.Path, "/operation/DescribeAlarms") || err != nil ||
+			!strings.Contains(r.Header.Get("Authorization"), "/monitoring/aws4_request") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var alarms cbor.List
+		for _, name := range in.(cbor.Map)["AlarmNames"].(cbor.List) {
+			alarms = append(alarms, cbor.Map{
+				"AlarmName":   name,
+				"StateValue":  cbor.String(state),
+				"StateReason": cbor.String("threshold crossed"),
+			})
+		}
+		w.Header().Set("Smithy-Protocol", "rpc-v2-cbor")
+		w.Header().Set("Content-Type", "application/cbor")
+		w.Write(cbor.Encode(cbor.Map{"MetricAlarms": alarms}))
+	}))
+	t.Cleanup(server.Close)
+	t.Setenv("AWS_ENDPOINT_URL_CLOUDWATCH", server.URL)
+}
+
+func canaryProject(t *testing.T) *tf.Fake {
+	t.Helper()
+	fake := apiProject(t)
+	writeManifest(t, ".", canaryConfig)
+	fake.Outputs["api_release_alarms"] = tf.Output{Value: json.RawMessage(`"shop-dev-api-release-errors,shop-dev-api-release-5xx"`)}
+	return fake
+}
+
+func TestGenerateReleaseAlarms(t *testing.T) {
+	dir := chdirTemp(t)
+	writeManifest(t, dir, canaryConfig)
+	if _, err := runCLI(t, &tf.Fake{}, "", "generate"); err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+
+	if !strings.Contains(readFile(t, "infra/main.tf"), "release_alarms = true") {
+		t.Error("Expected the api module to enable release alarms")
+	}
+
+	module := readFile(t, "infra/modules/web_api/main.tf")
+	for _, want := range []string{
+		`resource "aws_cloudwatch_metric_alarm" "release_errors"`,
+		`resource "aws_cloudwatch_metric_alarm" "release_5xx"`,
+		"count = var.release_alarms ? 1 : 0",
+		`Resource     = "${aws_lambda_function.this.function_name}:${aws_lambda_alias.live.name}"`,
+	} {
+		if !strings.Contains(module, want) {
+			t.Errorf("Expected the web_api module to contain %q", want)
+		}
+	}
+	if !strings.Contains(readFile(t, "infra/outputs.tf"), `output "api_release_alarms"`) {
+		t.Error("Expected an api_release_alarms output")
+	}
+}
+
+func TestDeployCanaryShiftsTrafficInSteps(t *testing.T) {
+	lambda := newFakeLambda(t)
+	fakeCloudWatch(t, "OK")
+	fake := canaryProject(t)
+
+	out, err := runCLI(t, fake, "", "deploy", "api")
+	if err != nil {
+		t.Fatalf("deploy failed: %v\n%s", err, out)
+	}
+
+	want := []string{"1 +2@0.1", "1 +2@0.5", "2"}
+	if strings.Join(lambda.routes, "|") != strings.Join(want, "|") {
+		t.Errorf("Expected alias routes %v, got %v", want, lambda.routes)
+	}
+	if strings.Join(lambda.checked, ",") != "2" {
+		t.Errorf("Expected one health check of version 2 before shifting traffic, got %v", lambda.checked)
+	}
+	for _, line := range []string{"Shifted 10% of alias live traffic to version 2", "Watching 2 alarms", "✓ Version 2 of api is live"} {
+		if !strings.Contains(out, line) {
+			t.Errorf("Expected output to contain %q\n%s", line, out)
+		}
+	}
+}
+
+func TestDeployCanaryRollsBackOnAlarm(t *testing.T) {
+	lambda := newFakeLambda(t)
+	fakeCloudWatch(t, "ALARM")
+	fake := canaryProject(t)
+
+	out, err := runCLI(t, fake, "", "deploy", "api")
+	if err == nil || !strings.Contains(err.Error(), "alarm shop-dev-api-release-errors is firing") {
+		t.Fatalf("Expected an alarm rollback, got %v\n%s", err, out)
+	}
+
+	// 10% canary, back to version 1, then the republished code of version 1
+	want := []string{"1 +2@0.1", "1", "3"}
+	if strings.Join(lambda.routes, "|") != strings.Join(want, "|") {
+		t.Errorf("Expected alias routes %v, got %v", want, lambda.routes)
+	}
+}
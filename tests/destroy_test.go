@@ -0,0 +1,156 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/OplexTech/soloops-cli/pkg/tf"
+)
+
+const protectedConfig = `apiVersion: soloops/v1
+project: shop
+cloud: aws
+environments:
+  - name: prod
+    region: us-east-1
+    budget_usd: 150
+    blueprints:
+      api:
+        type: web_api
+        runtime: node18
+      db:
+        type: database
+        db_type: postgres
+        protect: true
+`
+
+const protectedStateJSON = `{
+  "format_version": "1.0",
+  "values": {
+    "root_module": {
+      "resources": [
+        {"address": "aws_lambda_function.api", "mode": "managed", "type": "aws_lambda_function", "name": "api"},
+        {"address": "aws_iam_role.api_lambda_role", "mode": "managed", "type": "aws_iam_role", "name": "api_lambda_role"},
+        {"address": "aws_db_instance.db", "mode": "managed", "type": "aws_db_instance", "name": "db"},
+        {"address": "aws_budgets_budget.monthly", "mode": "managed", "type": "aws_budgets_budget", "name": "monthly"},
+        {"address": "data.aws_caller_identity.current", "mode": "data", "type": "aws_caller_identity", "name": "current"}
+      ]
+    }
+  }
+}`
+
+func generateProtectedProject(t *testing.T) {
+	t.Helper()
+	dir := chdirTemp(t)
+	writeManifest(t, dir, protectedConfig)
+	if _, err := runCLI(t, &tf.Fake{}, "", "generate"); err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+}
+
+func TestGenerateProtectedDatabase(t *testing.T) {
+	generateProtectedProject(t)
+
+	main := readFile(t, "infra/main.tf")
+	for _, want := range []string{
+		`source = "./modules/rds_protected"`,
+		`source = "./modules/web_api"`,
+		`final_snapshot_identifier = "shop-prod-db-final"`,
+	} {
+		if !strings.Contains(main, want) {
+			t.Errorf("Expected main.tf to contain %q", want)
+		}
+	}
+
+	db := readFile(t, "infra/modules/rds_protected/main.tf")
+	for _, want := range []string{
+		`resource "aws_db_instance" "this"`,
+		"deletion_protection         = true",
+		"manage_master_user_password = true",
+		"lifecycle {\n    prevent_destroy = true\n  }",
+	} {
+		if !strings.Contains(db, want) {
+			t.Errorf("Expected the rds_protected module to contain %q", want)
+		}
+	}
+
+	if strings.Contains(readFile(t, "infra/modules/web_api/main.tf"), "prevent_destroy") {
+		t.Error("Unprotected blueprints should not get prevent_destroy")
+	}
+}
+
+func TestDestroyRefusesProtectedBlueprints(t *testing.T) {
+	generateProtectedProject(t)
+	fake := &tf.Fake{ShowJSON: []byte(protectedStateJSON)}
+
+	_, err := runCLI(t, fake, "prod\n", "destroy")
+	if err == nil || !strings.Contains(err.Error(), "db: aws_db_instance.db") {
+		t.Fatalf("Expected protected blueprint error listing resources, got %v", err)
+	}
+	if !reflect.DeepEqual(fake.Methods(), []string{"init", "show"}) {
+		t.Errorf("Expected no destroy call, got %v", fake.Methods())
+	}
+
+	_, err = runCLI(t, fake, "", "destroy", "--target", "db", "--auto-approve")
+	if err == nil || !strings.Contains(err.Error(), "refusing to destroy protected blueprints") {
+		t.Errorf("Expected targeted destroy of a protected blueprint to fail, got %v", err)
+	}
+}
+
+func TestDestroyTargetBlueprint(t *testing.T) {
+	generateProtectedProject(t)
+	fake := &tf.Fake{ShowJSON: []byte(protectedStateJSON)}
+
+	if _, err := runCLI(t, fake, "", "destroy", "--target", "api", "--auto-approve"); err != nil {
+		t.Fatalf("destroy failed: %v", err)
+	}
+
+	calls := fake.Calls()
+	if len(calls) != 3 || calls[2].Method != "destroy" {
+		t.Fatalf("Expected init, show, destroy; got %v", fake.Methods())
+	}
+	opts := calls[2].Options.(tf.DestroyOptions)
+	if !opts.AutoApprove || !reflect.DeepEqual(opts.Targets, []string{"aws_lambda_function.api", "aws_iam_role.api_lambda_role"}) {
+		t.Errorf("Unexpected destroy options: %+v", opts)
+	}
+
+	if _, err := runCLI(t, &tf.Fake{}, "", "destroy", "--target", "worker"); err == nil {
+		t.Error("Expected error for unknown blueprint")
+	}
+}
+
+func TestDestroyProductionConfirmation(t *testing.T) {
+	generateProtectedProject(t)
+
+	fake := &tf.Fake{ShowJSON: []byte(protectedStateJSON)}
+	out, err := runCLI(t, fake, "destroy\n", "destroy", "--target", "api")
+	if err != nil {
+		t.Fatalf("destroy failed: %v", err)
+	}
+	if !strings.Contains(out, "Type the environment name (prod)") || !strings.Contains(out, "Aborted.") {
+		t.Errorf("Expected 'destroy' to be rejected for prod:\n%s", out)
+	}
+
+	fake = &tf.Fake{ShowJSON: []byte(protectedStateJSON)}
+	if _, err := runCLI(t, fake, "prod\n", "destroy", "--target", "api"); err != nil {
+		t.Fatalf("destroy failed: %v", err)
+	}
+	if got := fake.Methods(); got[len(got)-1] != "destroy" {
+		t.Errorf("Expected destroy after typing the environment name, got %v", got)
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/OplexTech/soloops-cli/pkg/cli"
+	"github.com/OplexTech/soloops-cli/pkg/plan"
+	"github.com/OplexTech/soloops-cli/pkg/tf"
+)
+
+const driftPlanJSON = `{
+  "format_version": "1.2",
+  "resource_drift": [
+    {"address": "aws_lambda_function.api", "mode": "managed", "type": "aws_lambda_function", "name": "api", "change": {"actions": ["update"]}},
+    {"address": "aws_iam_role.api_lambda_role", "mode": "managed", "type": "aws_iam_role", "name": "api_lambda_role", "change": {"actions": ["delete"]}}
+  ],
+  "resource_changes": []
+}`
+
+func TestDriftNone(t *testing.T) {
+	generateProject(t)
+	fake := &tf.Fake{}
+
+	stdout, _, err := runCLISplit(t, fake, "drift")
+	if err != nil {
+		t.Fatalf("Expected no drift, got %v", err)
+	}
+	if !strings.Contains(stdout, "✓ No drift in dev") {
+		t.Errorf("Unexpected report:\n%s", stdout)
+	}
+
+	calls := fake.Calls()
+	if len(calls) != 3 || calls[1].Method != "plan" {
+		t.Fatalf("Expected init, plan, show; got %v", fake.Methods())
+	}
+	if opts := calls[1].Options.(tf.PlanOptions); !opts.RefreshOnly || opts.Out == "" {
+		t.Errorf("Expected a saved refresh-only plan, got %+v", opts)
+	}
+}
+
+func TestDriftDetected(t *testing.T) {
+	generateProject(t)
+	fake := &tf.Fake{Changes: true, ShowJSON: []byte(driftPlanJSON)}
+
+	stdout, _, err := runCLISplit(t, fake, "drift")
+
+	var exitErr *cli.ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != cli.ExitDrift {
+		t.Fatalf("Expected drift exit code %d, got %v", cli.ExitDrift, err)
+	}
+	for _, want := range []string{
+		"Drift detected in dev (2 resources)",
+		"  api:\n",
+		"~   aws_lambda_function.api (changed outside Terraform)",
+		"-   aws_iam_role.api_lambda_role (deleted outside Terraform)",
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("Expected report to contain %q\n%s", want, stdout)
+		}
+	}
+}
+
+func TestDriftJSONOutput(t *testing.T) {
+	generateProject(t)
+	fake := &tf.Fake{Changes: true, ShowJSON: []byte(driftPlanJSON)}
+
+	stdout, _, err := runCLISplit(t, fake, "drift", "--output", "json")
+	if err == nil {
+		t.Fatal("Expected drift to be reported through an exit error")
+	}
+
+	var summary plan.Summary
+	if err := json.Unmarshal([]byte(stdout), &summary); err != nil {
+		t.Fatalf("Expected JSON report: %v\n%s", err, stdout)
+	}
+	if len(summary.Groups) != 1 || summary.Groups[0].Blueprint != "api" || summary.Totals.Update != 1 || summary.Totals.Delete != 1 {
+		t.Errorf("Unexpected drift summary: %+v", summary)
+	}
+}
@@ -101,16 +101,56 @@ func TestGeneratorGenerate(t *testing.T) {
 		t.Fatalf("Failed to read main.tf: %v", err)
 	}
 
-	if !strings.Contains(string(mainContent), "aws_lambda_function") {
-		t.Error("main.tf should contain Lambda function for web_api")
+	if !strings.Contains(string(mainContent), "module \"web_api\" {\n  source = \"./modules/web_api\"") {
+		t.Error("main.tf should instantiate the web_api module for web_api")
 	}
 
-	if !strings.Contains(string(mainContent), "aws_s3_bucket") {
-		t.Error("main.tf should contain S3 bucket for static_site")
+	if !strings.Contains(string(mainContent), "module \"static_site\" {\n  source = \"./modules/static_site\"") {
+		t.Error("main.tf should instantiate the static_site module for static_site")
 	}
 
-	if !strings.Contains(string(mainContent), "aws_cloudfront_distribution") {
-		t.Error("main.tf should contain CloudFront distribution for static_site")
+	// Modules are written once and shared by the blueprints using them
+	webAPIModule, err := os.ReadFile("infra/modules/web_api/main.tf")
+	if err != nil {
+		t.Fatalf("Failed to read web_api module: %v", err)
+	}
+
+	if !strings.Contains(string(webAPIModule), `resource "aws_lambda_alias" "live"`) ||
+		!strings.Contains(string(webAPIModule), "integration_uri  = aws_lambda_alias.live.invoke_arn") {
+		t.Error("web_api module should route API Gateway through the live alias")
+	}
+
+	staticSiteModule, err := os.ReadFile("infra/modules/static_site/main.tf")
+	if err != nil {
+		t.Fatalf("Failed to read static_site module: %v", err)
+	}
+
+	if !strings.Contains(string(staticSiteModule), "aws_s3_bucket") {
+		t.Error("static_site module should contain S3 bucket")
+	}
+
+	if !strings.Contains(string(staticSiteModule), "aws_cloudfront_distribution") {
+		t.Error("static_site module should contain CloudFront distribution")
+	}
+
+	// State from earlier versions follows the resources into the modules
+	movedContent, err := os.ReadFile("infra/moved.tf")
+	if err != nil {
+		t.Fatalf("Failed to read moved.tf: %v", err)
+	}
+
+	if !strings.Contains(string(movedContent), "from = aws_lambda_alias.web_api_live\n  to   = module.web_api.aws_lambda_alias.live") {
+		t.Error("moved.tf should move the flat live alias into the web_api module")
+	}
+
+	// Check outputs.tf content
+	outputsContent, err := os.ReadFile("infra/outputs.tf")
+	if err != nil {
+		t.Fatalf("Failed to read outputs.tf: %v", err)
+	}
+
+	if !strings.Contains(string(outputsContent), `output "static_site_cloudfront_distribution_id"`) {
+		t.Error("outputs.tf should contain the CloudFront distribution ID for static_site")
 	}
 
 	// Check budget.tf content
@@ -0,0 +1,216 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/OplexTech/soloops-cli/pkg/config"
+	"github.com/OplexTech/soloops-cli/pkg/generator"
+	"github.com/OplexTech/soloops-cli/pkg/tf"
+)
+
+const graphManifest = `project: shop
+cloud: aws
+environments:
+  - name: dev
+    region: us-east-1
+    budget_usd: 10
+    blueprints:
+      api:
+        type: web_api
+        uses: [worker, orders_db]
+      worker:
+        type: web_api
+        uses: [orders_db]
+      orders_db:
+        type: database
+        db_type: postgres
+      site:
+        type: static_site
+`
+
+func TestEnvironmentOrder(t *testing.T) {
+	env := &config.Environment{Blueprints: map[string]config.Blueprint{
+		"api":       {Type: config.KindWebAPI, Uses: []string{"worker", "orders_db"}},
+		"worker":    {Type: config.KindWebAPI, Uses: []string{"orders_db"}},
+		"orders_db": {Type: config.KindDatabase, DBType: "postgres"},
+		"site":      {Type: config.KindStaticSite},
+	}}
+
+	order, err := env.Order()
+	if err != nil {
+		t.Fatalf("Order failed: %v", err)
+	}
+	if want := []string{"orders_db", "site", "worker", "api"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("Expected %v, got %v", want, order)
+	}
+}
+
+func TestConfigRejectsDependencyCycles(t *testing.T) {
+	cfg := &config.Config{
+		Project: "shop",
+		Cloud:   "aws",
+		Environments: []config.Environment{{
+			Name: "dev", Region: "us-east-1", BudgetUSD: 10,
+			Blueprints: map[string]config.Blueprint{
+				"api":    {Type: config.KindWebAPI, Uses: []string{"worker"}},
+				"worker": {Type: config.KindWebAPI, Uses: []string{"jobs"}},
+				"jobs":   {Type: config.KindWebAPI, Uses: []string{"worker"}},
+			},
+		}},
+	}
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "dependency cycle: worker -> jobs -> worker") {
+		t.Errorf("Expected a dependency cycle error, got %v", err)
+	}
+}
+
+func TestGenerateOrdersBlueprintsByDependency(t *testing.T) {
+	chdirTemp(t)
+
+	cfg := &config.Config{Project: "shop", Cloud: "aws"}
+	env := &config.Environment{
+		Name:   "dev",
+		Region: "us-east-1",
+		Blueprints: map[string]config.Blueprint{
+			"api":    {Type: config.KindWebAPI, Uses: []string{"worker"}},
+			"worker": {Type: config.KindWebAPI},
+		},
+	}
+	if err := generator.New(cfg, env).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	for file, prefix := range map[string]string{"infra/main.tf": "# Blueprint: ", "infra/outputs.tf": `output "`} {
+		content := readFile(t, file)
+		if strings.Index(content, prefix+"worker") > strings.Index(content, prefix+"api") {
+			t.Errorf("%s should list worker before api, which uses it:\n%s", file, content)
+		}
+	}
+}
+
+func TestGraphDot(t *testing.T) {
+	dir := chdirTemp(t)
+	writeManifest(t, dir, graphManifest)
+
+	out, err := runCLI(t, &tf.Fake{}, "", "graph")
+	if err != nil {
+		t.Fatalf("graph failed: %v\n%s", err, out)
+	}
+	want := `digraph "shop-dev" {
+  rankdir=LR;
+  node [shape=box];
+
+  "orders_db" [label="orders_db\npostgres", shape=cylinder];
+  "site" [label="site\nstatic_site"];
+  "worker" [label="worker\nweb_api"];
+  "api" [label="api\nweb_api"];
+
+  "worker" -> "orders_db";
+  "api" -> "worker";
+  "api" -> "orders_db";
+}
+`
+	if out != want {
+		t.Errorf("Unexpected graph:\n%s", out)
+	}
+}
+
+func TestGraphMermaid(t *testing.T) {
+	dir := chdirTemp(t)
+	writeManifest(t, dir, graphManifest)
+
+	out, err := runCLI(t, &tf.Fake{}, "", "graph", "--format", "mermaid")
+	if err != nil {
+		t.Fatalf("graph failed: %v\n%s", err, out)
+	}
+	for _, want := range []string{
+		"flowchart LR\n",
+		`orders_db[("orders_db (postgres)")]`,
+		`site["site (static_site)"]`,
+		"worker --> orders_db\n",
+		"api --> worker\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected graph to contain %q:\n%s", want, out)
+		}
+	}
+
+	if _, err := runCLI(t, &tf.Fake{}, "", "graph", "--format", "svg"); err == nil || !strings.Contains(err.Error(), `unknown format "svg"`) {
+		t.Errorf("Expected an unknown format error, got %v", err)
+	}
+}
+
+func TestStaticSiteAPIOrigin(t *testing.T) {
+	chdirTemp(t)
+
+	env := &config.Environment{
+		Name:   "dev",
+		Region: "us-east-1",
+		Blueprints: map[string]config.Blueprint{
+			"api":  {Type: config.KindWebAPI},
+			"site": {Type: config.KindStaticSite, APIOrigin: "api"},
+		},
+	}
+	if deps := env.Dependencies("site"); !reflect.DeepEqual(deps, []string{"api"}) {
+		t.Errorf("Expected site to depend on api, got %v", deps)
+	}
+
+	cfg := &config.Config{Project: "shop", Cloud: "aws"}
+	if err := generator.New(cfg, env).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if main := readFile(t, "infra/main.tf"); !strings.Contains(main, "api_domain  = module.api.api_domain") ||
+		strings.Index(main, `module "api"`) > strings.Index(main, `module "site"`) {
+		t.Errorf("site should receive the API's domain after api is declared:\n%s", main)
+	}
+	module := readFile(t, "infra/modules/static_site/main.tf")
+	for _, want := range []string{`dynamic "origin"`, `path_pattern             = "/api/*"`, `target_origin_id         = "api"`} {
+		if !strings.Contains(module, want) {
+			t.Errorf("static_site module should contain %q:\n%s", want, module)
+		}
+	}
+	if outputs := readFile(t, "infra/modules/web_api/outputs.tf"); !strings.Contains(outputs, `output "api_domain"`) {
+		t.Error("web_api module should output its domain")
+	}
+
+	for _, tt := range []struct {
+		blueprint config.Blueprint
+		wantErr   string
+	}{
+		{config.Blueprint{Type: config.KindStaticSite, APIOrigin: "orders"}, `api_origin: unknown blueprint "orders"`},
+		{config.Blueprint{Type: config.KindStaticSite, APIOrigin: "db"}, "api_origin: db is a database, not a web_api"},
+		{config.Blueprint{Type: config.KindWebAPI, APIOrigin: "api"}, "api_origin is only supported for static_site blueprints"},
+	} {
+		cfg := &config.Config{
+			Project: "shop",
+			Cloud:   "aws",
+			Environments: []config.Environment{{
+				Name: "dev", Region: "us-east-1", BudgetUSD: 10,
+				Blueprints: map[string]config.Blueprint{
+					"api":  {Type: config.KindWebAPI},
+					"db":   {Type: config.KindDatabase, DBType: "dynamodb"},
+					"site": tt.blueprint,
+				},
+			}},
+		}
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+		}
+	}
+}
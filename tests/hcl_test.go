@@ -0,0 +1,163 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/OplexTech/soloops-cli/pkg/config"
+	"github.com/OplexTech/soloops-cli/pkg/generator"
+	"github.com/OplexTech/soloops-cli/pkg/hcl"
+)
+
+func TestHCLRendersTerraformFmtLayout(t *testing.T) {
+	file := hcl.NewFile()
+	body := file.Body()
+	body.Comment("Generated")
+	res := body.Block("resource", "aws_s3_bucket", "site")
+	res.Set("bucket", hcl.Template(hcl.Ref("var", "project_name"), hcl.String("-site")))
+	res.Set("force_destroy", hcl.Bool(false))
+	res.Set("tags", hcl.NewObject().Set("Name", hcl.String("site")).Set("my tag", hcl.Number(1.5)))
+	res.Set("acl", hcl.String("private"))
+	res.Newline()
+	res.Block("lifecycle").Set("ignore_changes", hcl.List(hcl.Ref("tags"), hcl.Ref("acl")))
+	res.Block("versioning")
+
+	want := `# Generated
+resource "aws_s3_bucket" "site" {
+  bucket        = "${var.project_name}-site"
+  force_destroy = false
+  tags = {
+    Name     = "site"
+    "my tag" = 1.5
+  }
+  acl = "private"
+
+  lifecycle {
+    ignore_changes = [tags, acl]
+  }
+  versioning {}
+}
+`
+	if got := string(file.Bytes()); got != want {
+		t.Errorf("unexpected rendering:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestHCLSetReplacesInPlace(t *testing.T) {
+	file := hcl.NewFile()
+	body := file.Body()
+	body.Set("a", hcl.Number(1))
+	body.Set("bb", hcl.Number(2))
+	body.Set("a", hcl.Number(3))
+
+	if got := string(file.Bytes()); got != "a  = 3\nbb = 2\n" {
+		t.Errorf("unexpected rendering: %q", got)
+	}
+}
+
+func TestHCLEscapesStrings(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{`plain`, `"plain"`},
+		{`say "hi"`, `"say \"hi\""`},
+		{`back\slash`, `"back\\slash"`},
+		{"two\nlines", `"two\nlines"`},
+		{"${var.secret}", `"$${var.secret}"`},
+		{"%{if true}", `"%%{if true}"`},
+		{"$5 and 100%", `"$5 and 100%"`},
+		{"bell\a", `"bell\u0007"`},
+	}
+
+	for _, tt := range tests {
+		file := hcl.NewFile()
+		file.Body().Set("v", hcl.String(tt.value))
+		if got := strings.TrimSpace(string(file.Bytes())); got != "v = "+tt.want {
+			t.Errorf("String(%q) rendered %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestHCLNestedCalls(t *testing.T) {
+	file := hcl.NewFile()
+	file.Body().Set("policy", hcl.Call("jsonencode", hcl.NewObject().
+		Set("Version", hcl.String("2012-10-17")).
+		Set("Statement", hcl.List(hcl.NewObject().Set("Effect", hcl.String("Allow"))))))
+
+	want := `policy = jsonencode({
+  Version = "2012-10-17"
+  Statement = [{
+    Effect = "Allow"
+  }]
+})
+`
+	if got := string(file.Bytes()); got != want {
+		t.Errorf("unexpected rendering:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestGeneratorEscapesManifestValues(t *testing.T) {
+	chdirTemp(t)
+
+	cfg := &config.Config{Project: `shop" } evil {`, Cloud: "aws"}
+	env := &config.Environment{
+		Name:   "dev",
+		Region: "us-east-1",
+		Blueprints: map[string]config.Blueprint{
+			"api": {
+				Type:    config.KindWebAPI,
+				Runtime: "node18",
+				Env: map[string]string{
+					"GREETING":  `say "hi" ${var.region}`,
+					"weird key": "x",
+				},
+			},
+		},
+	}
+	if err := generator.New(cfg, env).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	provider := readFile(t, "infra/provider.tf")
+	if !strings.Contains(provider, `Project     = "shop\" } evil {"`) {
+		t.Errorf("project name should be escaped in provider.tf:\n%s", provider)
+	}
+
+	main := readFile(t, "infra/main.tf")
+	for _, want := range []string{
+		`GREETING    = "say \"hi\" $${var.region}"`,
+		`"weird key" = "x"`,
+	} {
+		if !strings.Contains(main, want) {
+			t.Errorf("main.tf should contain %s:\n%s", want, main)
+		}
+	}
+
+	// Blocks stay balanced whatever the manifest contains
+	for _, name := range []string{"provider.tf", "main.tf", "variables.tf"} {
+		data, err := os.ReadFile("infra/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		content := strings.ReplaceAll(string(data), `\"`, "")
+		if open, closed := strings.Count(content, "{"), strings.Count(content, "}"); open != closed {
+			t.Errorf("%s has %d opening and %d closing braces", name, open, closed)
+		}
+	}
+}
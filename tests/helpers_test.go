@@ -0,0 +1,82 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/OplexTech/soloops-cli/pkg/cli"
+	"github.com/OplexTech/soloops-cli/pkg/tf"
+)
+
+func writeManifest(t *testing.T, dir, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, "soloops.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+	return path
+}
+
+// chdirTemp switches into a fresh temporary directory for the test
+func chdirTemp(t *testing.T) string {
+	t.Helper()
+	tmpDir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := os.Chdir(originalDir); err != nil {
+			t.Errorf("Failed to change back to original directory: %v", err)
+		}
+	})
+	return tmpDir
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", path, err)
+	}
+	return string(data)
+}
+
+// runCLI executes soloops with a fake Terraform runner and returns the output
+func runCLI(t *testing.T, fake *tf.Fake, stdin string, args ...string) (string, error) {
+	t.Helper()
+	previous := cli.SetRunnerFactory(fake.Runner)
+	t.Cleanup(func() { cli.SetRunnerFactory(previous) })
+
+	var out bytes.Buffer
+	err := cli.ExecuteArgs(args, strings.NewReader(stdin), &out, &out)
+	return out.String(), err
+}
+
+// runCLISplit is runCLI with stdout and stderr captured separately
+func runCLISplit(t *testing.T, fake *tf.Fake, args ...string) (string, string, error) {
+	t.Helper()
+	previous := cli.SetRunnerFactory(fake.Runner)
+	t.Cleanup(func() { cli.SetRunnerFactory(previous) })
+
+	var stdout, stderr bytes.Buffer
+	err := cli.ExecuteArgs(args, strings.NewReader(""), &stdout, &stderr)
+	return stdout.String(), stderr.String(), err
+}
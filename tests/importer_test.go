@@ -0,0 +1,203 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/OplexTech/soloops-cli/pkg/config"
+	"github.com/OplexTech/soloops-cli/pkg/importer"
+)
+
+const handWrittenState = `{
+  "version": 4,
+  "terraform_version": "1.6.0",
+  "resources": [
+    {"mode": "managed", "type": "aws_lambda_function", "name": "orders_handler", "instances": [{"attributes": {
+      "function_name": "shop-prod-orders_handler",
+      "arn": "arn:aws:lambda:eu-west-1:123456789012:function:shop-prod-orders_handler",
+      "invoke_arn": "arn:aws:apigateway:eu-west-1:lambda:path/2015-03-31/functions/arn:aws:lambda:eu-west-1:123456789012:function:shop-prod-orders_handler/invocations",
+      "role": "arn:aws:iam::123456789012:role/orders-role",
+      "runtime": "python3.12"}}]},
+    {"mode": "managed", "type": "aws_iam_role", "name": "orders", "instances": [{"attributes": {
+      "name": "orders-role", "arn": "arn:aws:iam::123456789012:role/orders-role"}}]},
+    {"mode": "managed", "type": "aws_iam_role_policy_attachment", "name": "orders_logs", "instances": [{"attributes": {
+      "role": "orders-role", "policy_arn": "arn:aws:iam::aws:policy/service-role/AWSLambdaBasicExecutionRole"}}]},
+    {"mode": "managed", "type": "aws_apigatewayv2_api", "name": "http", "instances": [{"attributes": {
+      "id": "a1b2c3", "name": "shop-prod-orders_handler"}}]},
+    {"mode": "managed", "type": "aws_apigatewayv2_integration", "name": "lambda", "instances": [{"attributes": {
+      "id": "int123", "api_id": "a1b2c3",
+      "integration_uri": "arn:aws:apigateway:eu-west-1:lambda:path/2015-03-31/functions/arn:aws:lambda:eu-west-1:123456789012:function:shop-prod-orders_handler/invocations"}}]},
+    {"mode": "managed", "type": "aws_apigatewayv2_route", "name": "default", "instances": [{"attributes": {
+      "id": "rt456", "api_id": "a1b2c3"}}]},
+    {"mode": "managed", "type": "aws_apigatewayv2_stage", "name": "default", "instances": [{"attributes": {
+      "id": "$default", "name": "$default", "api_id": "a1b2c3"}}]},
+    {"mode": "managed", "type": "aws_lambda_permission", "name": "apigw", "instances": [{"attributes": {
+      "function_name": "shop-prod-orders_handler", "statement_id": "AllowAPIGatewayInvoke"}}]},
+    {"mode": "managed", "type": "aws_s3_bucket", "name": "website", "module": "module.frontend", "instances": [{"attributes": {
+      "id": "shop-prod-website", "region": "eu-west-1",
+      "bucket_regional_domain_name": "shop-prod-website.s3.eu-west-1.amazonaws.com"}}]},
+    {"mode": "managed", "type": "aws_s3_bucket_policy", "name": "website", "module": "module.frontend", "instances": [{"attributes": {
+      "bucket": "shop-prod-website"}}]},
+    {"mode": "managed", "type": "aws_cloudfront_distribution", "name": "cdn", "module": "module.frontend", "instances": [{"attributes": {
+      "id": "E2ABCDEF", "aliases": ["shop.example.com"],
+      "origin": [{"domain_name": "shop-prod-website.s3.eu-west-1.amazonaws.com",
+        "s3_origin_config": [{"origin_access_identity": "origin-access-identity/cloudfront/OAI123"}]}]}}]},
+    {"mode": "managed", "type": "aws_cloudfront_origin_access_identity", "name": "oai", "module": "module.frontend", "instances": [{"attributes": {
+      "id": "OAI123", "cloudfront_access_identity_path": "origin-access-identity/cloudfront/OAI123"}}]},
+    {"mode": "managed", "type": "aws_sqs_queue", "name": "jobs", "instances": [{"attributes": {"id": "jobs"}}]},
+    {"mode": "data", "type": "aws_caller_identity", "name": "current", "instances": [{"attributes": {}}]}
+  ]
+}`
+
+func loadTestState(t *testing.T) *importer.State {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "terraform.tfstate")
+	if err := os.WriteFile(path, []byte(handWrittenState), 0644); err != nil {
+		t.Fatalf("Failed to write state: %v", err)
+	}
+	state, err := importer.LoadState(path)
+	if err != nil {
+		t.Fatalf("Failed to load state: %v", err)
+	}
+	return state
+}
+
+func TestImportRecognisesBlueprints(t *testing.T) {
+	proposal := importer.Analyze(loadTestState(t))
+
+	if proposal.Region != "eu-west-1" {
+		t.Errorf("Expected region eu-west-1, got %q", proposal.Region)
+	}
+
+	api, ok := proposal.Blueprints["orders_handler"]
+	if !ok || api.Kind() != config.KindWebAPI || api.Runtime != "python3.12" {
+		t.Errorf("Expected python3.12 web_api 'orders_handler', got %+v", proposal.Blueprints)
+	}
+
+	site, ok := proposal.Blueprints["website"]
+	if !ok || site.Kind() != config.KindStaticSite || site.Domain != "shop.example.com" {
+		t.Errorf("Expected static_site 'website' for shop.example.com, got %+v", proposal.Blueprints)
+	}
+
+	if len(proposal.Unmatched) != 1 || proposal.Unmatched[0] != "aws_sqs_queue.jobs" {
+		t.Errorf("Expected only the SQS queue to be unmatched, got %v", proposal.Unmatched)
+	}
+
+	cfg := proposal.Manifest("shop", "prod", 100)
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Proposed manifest is invalid: %v", err)
+	}
+}
+
+func TestImportRenderImportBlocks(t *testing.T) {
+	proposal := importer.Analyze(loadTestState(t))
+
+	out, err := proposal.Render(importer.StrategyImport, "terraform.tfstate")
+	if err != nil {
+		t.Fatalf("Failed to render: %v", err)
+	}
+
+	for _, want := range []string{
+		"import {\n  to = module.orders_handler.aws_lambda_function.this\n  id = \"shop-prod-orders_handler\"\n}",
+		"to = module.orders_handler.aws_iam_role.lambda\n  id = \"orders-role\"",
+		"to = module.orders_handler.aws_iam_role_policy_attachment.lambda\n  id = \"orders-role/arn:aws:iam::aws:policy/service-role/AWSLambdaBasicExecutionRole\"",
+		"to = module.orders_handler.aws_apigatewayv2_integration.this\n  id = \"a1b2c3/int123\"",
+		"to = module.orders_handler.aws_apigatewayv2_route.this\n  id = \"a1b2c3/rt456\"",
+		"to = module.orders_handler.aws_apigatewayv2_stage.this\n  id = \"a1b2c3/$default\"",
+		"to = module.orders_handler.aws_lambda_permission.apigateway\n  id = \"shop-prod-orders_handler/AllowAPIGatewayInvoke\"",
+		"to = module.website.aws_s3_bucket_policy.this\n  id = \"shop-prod-website\"",
+		"to = module.website.aws_cloudfront_distribution.this\n  id = \"E2ABCDEF\"",
+		"to = module.website.aws_cloudfront_origin_access_identity.this\n  id = \"OAI123\"",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected imports to contain %q\n%s", want, out)
+		}
+	}
+
+	if strings.Contains(out, "aws_sqs_queue") {
+		t.Error("Unrecognised resources should not be imported")
+	}
+}
+
+func TestImportRenderMovedBlocks(t *testing.T) {
+	proposal := importer.Analyze(loadTestState(t))
+
+	out, err := proposal.Render(importer.StrategyMoved, "terraform.tfstate")
+	if err != nil {
+		t.Fatalf("Failed to render: %v", err)
+	}
+
+	for _, want := range []string{
+		"moved {\n  from = aws_iam_role.orders\n  to   = module.orders_handler.aws_iam_role.lambda\n}",
+		"from = module.frontend.aws_cloudfront_distribution.cdn\n  to   = module.website.aws_cloudfront_distribution.this",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected moved blocks to contain %q\n%s", want, out)
+		}
+	}
+
+	// Every resource moves into its blueprint's module
+	if !strings.Contains(out, "from = aws_lambda_function.orders_handler\n  to   = module.orders_handler.aws_lambda_function.this") {
+		t.Error("Expected a moved block into the blueprint module")
+	}
+
+	if _, err := proposal.Render("copy", "terraform.tfstate"); err == nil {
+		t.Error("Expected error for unknown strategy")
+	}
+}
+
+func TestImportNameMismatches(t *testing.T) {
+	proposal := importer.Analyze(loadTestState(t))
+
+	// The bucket keeps its name; only the IAM role differs
+	mismatches := proposal.NameMismatches("shop", "prod")
+	if len(mismatches) != 1 || !strings.Contains(mismatches[0], "module.orders_handler.aws_iam_role.lambda") {
+		t.Errorf("Expected the IAM role name to mismatch, got %v", mismatches)
+	}
+	if proposal.Manifest("shop", "prod", 50).AccountSuffix() {
+		t.Error("Expected no account suffix for buckets named without one")
+	}
+}
+
+func TestImportNameMatchesAccountSuffix(t *testing.T) {
+	proposal := &importer.Proposal{Groups: []importer.Group{{
+		Blueprint: "website",
+		Kind:      config.KindStaticSite,
+		Adoptions: []importer.Adoption{{
+			To: "module.website.aws_s3_bucket.this", Name: "shop-prod-website-123456789012", NameSuffix: "website",
+		}},
+	}}}
+
+	if mismatches := proposal.NameMismatches("shop", "prod"); len(mismatches) != 0 {
+		t.Errorf("Expected a bucket with the account ID suffix to match, got %v", mismatches)
+	}
+	if !proposal.Manifest("shop", "prod", 50).AccountSuffix() {
+		t.Error("Expected the manifest to keep the account ID suffix")
+	}
+}
+
+func TestImportRejectsOldStateFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "terraform.tfstate")
+	if err := os.WriteFile(path, []byte(`{"version": 3}`), 0644); err != nil {
+		t.Fatalf("Failed to write state: %v", err)
+	}
+	if _, err := importer.LoadState(path); err == nil {
+		t.Error("Expected error for state format version 3")
+	}
+}
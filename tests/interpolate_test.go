@@ -0,0 +1,203 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/OplexTech/soloops-cli/pkg/config"
+	"github.com/OplexTech/soloops-cli/pkg/generator"
+)
+
+const interpolatedConfig = `project: ${PROJECT}
+cloud: aws
+environments:
+  - name: prod
+    region: ${REGION:-us-east-1}
+    budget_usd: ${BUDGET}
+    blueprints:
+      web_api:
+        runtime: node18
+        env:
+          DOMAIN: ${DOMAIN}
+          TEMPLATE: $${NOT_A_VAR}
+          DB_PASSWORD: secret://aws-ssm/prod/db/password
+`
+
+func noEnv(string) (string, bool) { return "", false }
+
+func TestLoadInterpolation(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := writeManifest(t, tmpDir, interpolatedConfig)
+
+	envFile := "PROJECT=from-file\nBUDGET=75\n# comment\nexport DOMAIN=\"file.example.com\"\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, config.EnvFileName), []byte(envFile), 0644); err != nil {
+		t.Fatalf("Failed to write env file: %v", err)
+	}
+
+	cfg, err := config.LoadWithOptions(path, config.LoadOptions{
+		Vars: map[string]string{"PROJECT": "from-var"},
+		LookupEnv: func(name string) (string, bool) {
+			if name == "PROJECT" || name == "DOMAIN" {
+				return "from-env", true
+			}
+			return "", false
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	if cfg.Project != "from-var" {
+		t.Errorf("Expected --var to win, got project %q", cfg.Project)
+	}
+
+	env := cfg.Environments[0]
+	if env.Region != "us-east-1" {
+		t.Errorf("Expected default region, got %q", env.Region)
+	}
+	if env.BudgetUSD != 75 {
+		t.Errorf("Expected budget from env file to decode as a number, got %v", env.BudgetUSD)
+	}
+
+	bp := env.Blueprints["web_api"]
+	if bp.Env["DOMAIN"] != "from-env" {
+		t.Errorf("Expected environment to override env file, got %q", bp.Env["DOMAIN"])
+	}
+	if bp.Env["TEMPLATE"] != "${NOT_A_VAR}" {
+		t.Errorf("Expected escaped placeholder to be literal, got %q", bp.Env["TEMPLATE"])
+	}
+
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected interpolated config to be valid: %v", err)
+	}
+}
+
+func TestLoadUndefinedVariable(t *testing.T) {
+	path := writeManifest(t, t.TempDir(), interpolatedConfig)
+
+	_, err := config.LoadWithOptions(path, config.LoadOptions{LookupEnv: noEnv})
+	if err == nil {
+		t.Fatal("Expected error for undefined variables")
+	}
+	for _, name := range []string{"BUDGET", "DOMAIN", "PROJECT"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("Expected error to mention %s, got: %v", name, err)
+		}
+	}
+}
+
+func TestLoadEnvironmentEnvFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := writeManifest(t, tmpDir, interpolatedConfig)
+
+	files := map[string]string{
+		config.EnvFileName:  "PROJECT=shared\nBUDGET=10\nDOMAIN=shared.example.com\n",
+		".soloops.prod.env": "BUDGET=500\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	cfg, err := config.LoadWithOptions(path, config.LoadOptions{Environment: "prod", LookupEnv: noEnv})
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if cfg.Environments[0].BudgetUSD != 500 {
+		t.Errorf("Expected per-environment file to override, got %v", cfg.Environments[0].BudgetUSD)
+	}
+}
+
+func TestParseSecretRef(t *testing.T) {
+	tests := []struct {
+		value       string
+		provider    string
+		path        string
+		expectError bool
+	}{
+		{"secret://aws-ssm/prod/db/password", config.SecretProviderSSM, "/prod/db/password", false},
+		{"secret://aws-secretsmanager/prod-api-key", config.SecretProviderSecretsManager, "prod-api-key", false},
+		{"secret://env/STRIPE_KEY", config.SecretProviderEnv, "STRIPE_KEY", false},
+		{"secret://vault/kv/thing", "", "", true},
+		{"secret://aws-ssm/", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			ref, err := config.ParseSecretRef(tt.value)
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if ref.Provider != tt.provider || ref.Path != tt.path {
+				t.Errorf("Expected %s %s, got %s %s", tt.provider, tt.path, ref.Provider, ref.Path)
+			}
+		})
+	}
+}
+
+func TestGeneratorSecrets(t *testing.T) {
+	chdirTemp(t)
+
+	cfg := &config.Config{Project: "test", Cloud: "aws"}
+	env := &config.Environment{
+		Name:      "prod",
+		Region:    "us-east-1",
+		BudgetUSD: 100,
+		Blueprints: map[string]config.Blueprint{
+			"api": {
+				Runtime: "node18",
+				Env: map[string]string{
+					"DB_PASSWORD": "secret://aws-ssm/prod/db/password",
+					"STRIPE_KEY":  "secret://env/STRIPE_KEY",
+					"GREETING":    `say "hi" ${there}`,
+				},
+			},
+		},
+	}
+
+	if err := generator.New(cfg, env).Generate(); err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+
+	secrets := readFile(t, "infra/secrets.tf")
+	if !strings.Contains(secrets, `data "aws_ssm_parameter" "prod_db_password"`) {
+		t.Error("secrets.tf should declare an SSM data source")
+	}
+	if !strings.Contains(secrets, `variable "secret_stripe_key"`) || !strings.Contains(secrets, "sensitive = true") {
+		t.Error("secrets.tf should declare a sensitive variable for env secrets")
+	}
+
+	main := readFile(t, "infra/main.tf")
+	if strings.Contains(main, "secret://") {
+		t.Error("main.tf should not contain secret references")
+	}
+	if !strings.Contains(main, "DB_PASSWORD = data.aws_ssm_parameter.prod_db_password.value") {
+		t.Error("main.tf should read DB_PASSWORD from the SSM data source")
+	}
+	if !strings.Contains(main, `"say \"hi\" $${there}"`) {
+		t.Error("main.tf should escape quotes and template sequences in plain values")
+	}
+}
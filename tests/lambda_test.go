@@ -0,0 +1,151 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/OplexTech/soloops-cli/pkg/config"
+	"github.com/OplexTech/soloops-cli/pkg/generator"
+)
+
+func TestConfigLoadsLambdaSettings(t *testing.T) {
+	dir := t.TempDir()
+	path := writeManifest(t, dir, `apiVersion: soloops/v1
+project: shop
+cloud: aws
+environments:
+  - name: dev
+    region: us-east-1
+    budget_usd: 10
+    blueprints:
+      api:
+        type: web_api
+        runtime: python3.12
+        memory: 1024
+        timeout: 20
+        architecture: arm64
+        layers:
+          - arn:aws:lambda:us-east-1:123456789012:layer:deps:3
+        reserved_concurrency: 0
+        tracing: true
+        dead_letter: arn:aws:sns:us-east-1:123456789012:failures
+`)
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+
+	bp := cfg.Environments[0].Blueprints["api"]
+	if bp.Memory != 1024 || bp.Timeout != 20 || bp.Architecture != config.ArchARM || !bp.Tracing ||
+		len(bp.Layers) != 1 || bp.ReservedConcurrency == nil || *bp.ReservedConcurrency != 0 ||
+		bp.DeadLetter != "arn:aws:sns:us-east-1:123456789012:failures" {
+		t.Errorf("Unexpected settings: %+v", bp.Lambda)
+	}
+	if len(bp.Raw) != 0 {
+		t.Errorf("Settings should not be left in Raw: %v", bp.Raw)
+	}
+}
+
+func TestConfigValidatesLambdaSettings(t *testing.T) {
+	negative := -1
+	tests := []struct {
+		blueprint config.Blueprint
+		wantErr   string
+	}{
+		{config.Blueprint{Type: config.KindWebAPI, Runtime: "go1.x"}, `unsupported runtime "go1.x"`},
+		{config.Blueprint{Type: config.KindWebAPI, Lambda: config.Lambda{Memory: 64}}, "memory 64 must be between 128 and 10240 MB"},
+		{config.Blueprint{Type: config.KindWebAPI, Lambda: config.Lambda{Timeout: 60}}, "timeout 60 must be between 1 and 29 seconds"},
+		{config.Blueprint{Type: config.KindWebAPI, Lambda: config.Lambda{Architecture: "ppc64"}}, `unsupported architecture "ppc64" (supported: x86_64, arm64)`},
+		{config.Blueprint{Type: config.KindWebAPI, Lambda: config.Lambda{Layers: []string{"deps"}}}, `layer "deps" is not a layer version ARN`},
+		{config.Blueprint{Type: config.KindWebAPI, Lambda: config.Lambda{Layers: make([]string, 6)}}, "at most 5 layers"},
+		{config.Blueprint{Type: config.KindWebAPI, Lambda: config.Lambda{ReservedConcurrency: &negative}}, "reserved_concurrency must not be negative"},
+		{config.Blueprint{Type: config.KindWebAPI, Lambda: config.Lambda{DeadLetter: "arn:aws:s3:::bucket"}}, "not an SQS queue or SNS topic ARN"},
+		{config.Blueprint{Type: config.KindStaticSite, Lambda: config.Lambda{Memory: 512}}, "function settings are only supported for web_api blueprints"},
+	}
+	for _, tt := range tests {
+		cfg := &config.Config{
+			Project: "shop",
+			Cloud:   "aws",
+			Environments: []config.Environment{{
+				Name: "dev", Region: "us-east-1", BudgetUSD: 10,
+				Blueprints: map[string]config.Blueprint{"api": tt.blueprint},
+			}},
+		}
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+		}
+	}
+}
+
+func TestGenerateLambdaSettings(t *testing.T) {
+	chdirTemp(t)
+
+	concurrency := 5
+	cfg := &config.Config{Project: "shop", Cloud: "aws"}
+	env := &config.Environment{
+		Name:   "dev",
+		Region: "us-east-1",
+		Blueprints: map[string]config.Blueprint{
+			"api": {Type: config.KindWebAPI, Runtime: "node20", Lambda: config.Lambda{
+				Memory:              512,
+				Architecture:        config.ArchARM,
+				ReservedConcurrency: &concurrency,
+				Tracing:             true,
+				DeadLetter:          "arn:aws:sqs:us-east-1:123456789012:api-dlq",
+			}},
+			"plain": {Type: config.KindWebAPI},
+		},
+	}
+	if err := generator.New(cfg, env).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	main := readFile(t, "infra/main.tf")
+	for _, want := range []string{
+		`runtime                = "nodejs20.x"`,
+		`architecture           = "arm64"`,
+		"memory_size            = 512",
+		"timeout                = 10",
+		"reserved_concurrency   = 5",
+		"tracing                = true",
+		`dead_letter_target_arn = "arn:aws:sqs:us-east-1:123456789012:api-dlq"`,
+		// Defaults are explicit for blueprints without settings
+		"runtime      = \"nodejs18.x\"\n  architecture = \"x86_64\"\n  memory_size  = 256\n  timeout      = 10\n",
+	} {
+		if !strings.Contains(main, want) {
+			t.Errorf("main.tf should contain %q:\n%s", want, main)
+		}
+	}
+
+	module := readFile(t, "infra/modules/web_api/main.tf")
+	for _, want := range []string{
+		"architectures = [var.architecture]",
+		"reserved_concurrent_executions = var.reserved_concurrency",
+		`mode = var.tracing ? "Active" : "PassThrough"`,
+		`dynamic "dead_letter_config"`,
+		`count = var.dead_letter_target_arn == "" ? 0 : 1`,
+		`policy_arn = "arn:aws:iam::aws:policy/AWSXRayDaemonWriteAccess"`,
+	} {
+		if !strings.Contains(module, want) {
+			t.Errorf("web_api module should contain %q", want)
+		}
+	}
+}
@@ -0,0 +1,275 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/OplexTech/soloops-cli/pkg/plan"
+	"github.com/OplexTech/soloops-cli/pkg/tf"
+)
+
+const lifecycleConfig = `apiVersion: soloops/v1
+project: shop
+cloud: aws
+environments:
+  - name: dev
+    region: us-east-1
+    budget_usd: 50
+    blueprints:
+      api:
+        type: web_api
+        runtime: node18
+`
+
+// generateProject writes a manifest and generates infra/ in a temp directory
+func generateProject(t *testing.T) {
+	t.Helper()
+	dir := chdirTemp(t)
+	writeManifest(t, dir, lifecycleConfig)
+	if _, err := runCLI(t, &tf.Fake{}, "", "generate"); err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+}
+
+func TestPreviewRunsInitAndPlan(t *testing.T) {
+	generateProject(t)
+	fake := &tf.Fake{Changes: true}
+
+	out, err := runCLI(t, fake, "", "preview")
+	if err != nil {
+		t.Fatalf("preview failed: %v", err)
+	}
+
+	if got := fake.Methods(); !reflect.DeepEqual(got, []string{"init", "plan", "show"}) {
+		t.Errorf("Expected init, plan, show; got %v", got)
+	}
+	for _, call := range fake.Calls() {
+		if call.Dir != "infra" {
+			t.Errorf("Expected %s to run in infra, got %q", call.Method, call.Dir)
+		}
+	}
+	if strings.Contains(out, "✓ No changes") {
+		t.Errorf("Did not expect a no-changes message:\n%s", out)
+	}
+}
+
+func TestPreviewReportsNoChanges(t *testing.T) {
+	generateProject(t)
+
+	out, err := runCLI(t, &tf.Fake{}, "", "preview")
+	if err != nil {
+		t.Fatalf("preview failed: %v", err)
+	}
+	if !strings.Contains(out, "✓ No changes") {
+		t.Errorf("Expected a no-changes message:\n%s", out)
+	}
+}
+
+func TestPreviewRequiresGeneratedFiles(t *testing.T) {
+	chdirTemp(t)
+	fake := &tf.Fake{}
+
+	if _, err := runCLI(t, fake, "", "preview"); err == nil || !strings.Contains(err.Error(), "soloops generate") {
+		t.Errorf("Expected missing infra/ error, got %v", err)
+	}
+	if len(fake.Calls()) != 0 {
+		t.Errorf("Expected no terraform calls, got %v", fake.Methods())
+	}
+}
+
+func TestApplyAutoApprove(t *testing.T) {
+	generateProject(t)
+	fake := &tf.Fake{}
+
+	out, err := runCLI(t, fake, "", "apply", "--auto-approve")
+	if err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+
+	calls := fake.Calls()
+	if len(calls) != 2 || calls[0].Method != "init" || calls[1].Method != "apply" {
+		t.Fatalf("Expected init, apply; got %v", fake.Methods())
+	}
+	if opts := calls[1].Options.(tf.ApplyOptions); !opts.AutoApprove {
+		t.Errorf("Expected auto-approve to be passed through, got %+v", opts)
+	}
+	if !strings.Contains(out, "provisioned successfully") {
+		t.Errorf("Expected success message:\n%s", out)
+	}
+}
+
+func TestApplyPromptsForConfirmation(t *testing.T) {
+	generateProject(t)
+
+	fake := &tf.Fake{}
+	out, err := runCLI(t, fake, "no\n", "apply")
+	if err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+	if !strings.Contains(out, "Aborted.") || !reflect.DeepEqual(fake.Methods(), []string{"init"}) {
+		t.Errorf("Expected apply to abort after init; calls %v\n%s", fake.Methods(), out)
+	}
+
+	fake = &tf.Fake{}
+	if _, err := runCLI(t, fake, "yes\n", "apply"); err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+	if !reflect.DeepEqual(fake.Methods(), []string{"init", "apply"}) {
+		t.Errorf("Expected init, apply; got %v", fake.Methods())
+	}
+}
+
+func TestApplyReportsTerraformFailure(t *testing.T) {
+	generateProject(t)
+	fake := &tf.Fake{Errors: map[string]error{"apply": errors.New("exit status 1")}}
+
+	_, err := runCLI(t, fake, "", "apply", "--auto-approve")
+	if err == nil || !strings.Contains(err.Error(), "terraform apply failed") {
+		t.Errorf("Expected terraform apply error, got %v", err)
+	}
+}
+
+func TestDestroyRequiresConfirmation(t *testing.T) {
+	generateProject(t)
+
+	fake := &tf.Fake{}
+	out, err := runCLI(t, fake, "no\n", "destroy")
+	if err != nil {
+		t.Fatalf("destroy failed: %v", err)
+	}
+	if !strings.Contains(out, "Aborted.") || !reflect.DeepEqual(fake.Methods(), []string{"init", "show"}) {
+		t.Errorf("Expected destroy to abort; calls %v\n%s", fake.Methods(), out)
+	}
+
+	fake = &tf.Fake{}
+	if _, err := runCLI(t, fake, "destroy\n", "destroy"); err != nil {
+		t.Fatalf("destroy failed: %v", err)
+	}
+	if !reflect.DeepEqual(fake.Methods(), []string{"init", "show", "destroy"}) {
+		t.Errorf("Expected init, show, destroy; got %v", fake.Methods())
+	}
+}
+
+func TestPreviewSavesPlan(t *testing.T) {
+	generateProject(t)
+	fake := &tf.Fake{Changes: true, ShowJSON: []byte(`{"format_version":"1.2"}`)}
+
+	out, err := runCLI(t, fake, "", "preview")
+	if err != nil {
+		t.Fatalf("preview failed: %v", err)
+	}
+
+	if got := fake.Methods(); !reflect.DeepEqual(got, []string{"init", "plan", "show"}) {
+		t.Errorf("Expected init, plan, show; got %v", got)
+	}
+	opts := fake.Calls()[1].Options.(tf.PlanOptions)
+	if !filepath.IsAbs(opts.Out) || !strings.HasSuffix(opts.Out, filepath.Join("infra", "soloops.tfplan")) {
+		t.Errorf("Expected an absolute plan path, got %q", opts.Out)
+	}
+
+	if got := readFile(t, plan.JSONFile(plan.DefaultFile)); got != `{"format_version":"1.2"}` {
+		t.Errorf("Unexpected plan JSON: %s", got)
+	}
+	meta, err := plan.ReadMeta(plan.DefaultFile)
+	if err != nil {
+		t.Fatalf("Failed to read plan metadata: %v", err)
+	}
+	if meta.Environment != "dev" || meta.Hash == "" {
+		t.Errorf("Unexpected plan metadata: %+v", meta)
+	}
+	if !strings.Contains(out, "soloops apply --plan infra/soloops.tfplan") {
+		t.Errorf("Expected apply hint:\n%s", out)
+	}
+}
+
+func TestApplySavedPlan(t *testing.T) {
+	generateProject(t)
+	if _, err := runCLI(t, &tf.Fake{}, "", "preview"); err != nil {
+		t.Fatalf("preview failed: %v", err)
+	}
+
+	fake := &tf.Fake{}
+	if _, err := runCLI(t, fake, "", "apply", "--plan", plan.DefaultFile, "--auto-approve"); err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+
+	calls := fake.Calls()
+	if len(calls) != 2 || calls[1].Method != "apply" {
+		t.Fatalf("Expected init, apply; got %v", fake.Methods())
+	}
+	if opts := calls[1].Options.(tf.ApplyOptions); !strings.HasSuffix(opts.PlanFile, filepath.Join("infra", "soloops.tfplan")) {
+		t.Errorf("Expected the saved plan to be applied, got %+v", opts)
+	}
+}
+
+func TestApplyRefusesStalePlan(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(t *testing.T)
+	}{
+		{"manifest", func(t *testing.T) {
+			writeManifest(t, ".", strings.Replace(lifecycleConfig, "budget_usd: 50", "budget_usd: 75", 1))
+		}},
+		{"generated files", func(t *testing.T) {
+			main := readFile(t, "infra/main.tf")
+			if err := os.WriteFile("infra/main.tf", []byte(main+"\n# edited\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}},
+		{"environment", func(t *testing.T) {}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generateProject(t)
+			if _, err := runCLI(t, &tf.Fake{}, "", "preview"); err != nil {
+				t.Fatalf("preview failed: %v", err)
+			}
+			tt.change(t)
+
+			args := []string{"apply", "--plan", plan.DefaultFile, "--auto-approve"}
+			if tt.name == "environment" {
+				args = append(args, "--env", "prod")
+			}
+
+			fake := &tf.Fake{}
+			_, err := runCLI(t, fake, "", args...)
+			if err == nil || !strings.Contains(err.Error(), "refusing to apply") {
+				t.Errorf("Expected stale plan error, got %v", err)
+			}
+			if len(fake.Calls()) != 0 {
+				t.Errorf("Expected no terraform calls, got %v", fake.Methods())
+			}
+		})
+	}
+}
+
+func TestApplyRejectsUnsavedPlan(t *testing.T) {
+	generateProject(t)
+	if err := os.WriteFile("other.tfplan", []byte("plan"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := runCLI(t, &tf.Fake{}, "", "apply", "--plan", "other.tfplan", "--auto-approve")
+	if err == nil || !strings.Contains(err.Error(), "not saved by 'soloops preview'") {
+		t.Errorf("Expected missing metadata error, got %v", err)
+	}
+}
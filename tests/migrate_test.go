@@ -0,0 +1,160 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/OplexTech/soloops-cli/pkg/config"
+	"github.com/OplexTech/soloops-cli/pkg/diff"
+)
+
+const legacyConfig = `# Acme infrastructure
+project: acme-api
+cloud: aws
+environments:
+  - name: prod
+    region: us-east-1 # primary region
+    budget_usd: 150
+    blueprints:
+      web_api:
+        runtime: node18
+        ingress: edge
+      static_site:
+        domain: acme.com
+      database:
+        type: aurora_serverless_v2
+`
+
+func TestMigrateLegacyManifest(t *testing.T) {
+	migrated, result, err := config.MigrateBytes([]byte(legacyConfig))
+	if err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+
+	if !result.Changed || result.From != config.APIVersionV1Alpha1 || result.To != config.CurrentAPIVersion {
+		t.Errorf("Unexpected migration result: %+v", result)
+	}
+
+	out := string(migrated)
+	for _, want := range []string{
+		"# Acme infrastructure\napiVersion: soloops/v1\nproject: acme-api",
+		"region: us-east-1 # primary region",
+		"web_api:\n        type: web_api\n        runtime: node18",
+		"static_site:\n        type: static_site",
+		"database:\n        type: database\n        db_type: aurora_serverless_v2",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Migrated manifest missing %q:\n%s", want, out)
+		}
+	}
+
+	// Migrating again is a no-op
+	again, result, err := config.MigrateBytes(migrated)
+	if err != nil {
+		t.Fatalf("Failed to re-migrate: %v", err)
+	}
+	if result.Changed || string(again) != out {
+		t.Error("Expected migrating the latest version to be a no-op")
+	}
+}
+
+func TestLoadLegacyManifest(t *testing.T) {
+	path := writeManifest(t, t.TempDir(), legacyConfig)
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	if cfg.APIVersion != config.CurrentAPIVersion {
+		t.Errorf("Expected in-memory upgrade to %s, got %q", config.CurrentAPIVersion, cfg.APIVersion)
+	}
+
+	db := cfg.Environments[0].Blueprints["database"]
+	if db.Type != config.KindDatabase || db.DBType != "aurora_serverless_v2" {
+		t.Errorf("Expected legacy database type to become db_type, got type=%q db_type=%q", db.Type, db.DBType)
+	}
+	if kind := cfg.Environments[0].Blueprints["web_api"].Kind(); kind != config.KindWebAPI {
+		t.Errorf("Expected web_api kind, got %q", kind)
+	}
+}
+
+func TestV1RequiresBlueprintType(t *testing.T) {
+	const untyped = `project: acme-api
+cloud: aws
+environments:
+  - name: prod
+    region: us-east-1
+    budget_usd: 150
+    blueprints:
+      web_api:
+        runtime: node18
+`
+	cfg, err := config.Load(writeManifest(t, t.TempDir(), "apiVersion: soloops/v1\n"+untyped))
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	err = cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "blueprint web_api: type is required by soloops/v1") {
+		t.Errorf("Expected a missing type error, got %v", err)
+	}
+
+	// The same blueprint is inferred as a web_api in v1alpha1
+	cfg, err = config.Load(writeManifest(t, t.TempDir(), untyped))
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected the migrated legacy manifest to be valid, got %v", err)
+	}
+}
+
+func TestMigrateUnsupportedVersion(t *testing.T) {
+	_, _, err := config.MigrateBytes([]byte("apiVersion: soloops/v9\nproject: x\n"))
+	if err == nil || !strings.Contains(err.Error(), "unsupported apiVersion") {
+		t.Errorf("Expected unsupported apiVersion error, got: %v", err)
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	oldText := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\n"
+	newText := "a\nb\nc\nd\nE\nf\ng\nh\ni\nj\nk\n"
+
+	got := diff.Unified("old", "new", oldText, newText)
+	want := `--- old
++++ new
+@@ -2,9 +2,10 @@
+ b
+ c
+ d
+-e
++E
+ f
+ g
+ h
+ i
+ j
++k
+`
+	if got != want {
+		t.Errorf("Unexpected diff:\n%s", got)
+	}
+
+	if diff.Unified("old", "new", oldText, oldText) != "" {
+		t.Error("Expected empty diff for identical input")
+	}
+}
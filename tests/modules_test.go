@@ -0,0 +1,180 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/OplexTech/soloops-cli/pkg/config"
+	"github.com/OplexTech/soloops-cli/pkg/generator"
+)
+
+func moduleTestEnv() *config.Environment {
+	return &config.Environment{
+		Name:   "dev",
+		Region: "us-east-1",
+		Blueprints: map[string]config.Blueprint{
+			"orders":   {Type: config.KindWebAPI, Runtime: "node18"},
+			"payments": {Type: config.KindWebAPI, Runtime: "node18"},
+			"db":       {Type: config.KindDatabase, DBType: "aurora_serverless_v2"},
+		},
+	}
+}
+
+func TestGenerateLocalModules(t *testing.T) {
+	chdirTemp(t)
+
+	cfg := &config.Config{Project: "shop", Cloud: "aws"}
+	if err := generator.New(cfg, moduleTestEnv()).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	// Both web APIs share one copy of the module
+	entries, err := os.ReadDir("infra/modules")
+	if err != nil {
+		t.Fatalf("Failed to read modules: %v", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	if strings.Join(names, ",") != "aurora_serverless_v2,web_api" {
+		t.Errorf("Unexpected modules: %v", names)
+	}
+	for _, file := range []string{"main.tf", "variables.tf", "outputs.tf", "versions.tf"} {
+		if _, err := os.Stat("infra/modules/web_api/" + file); err != nil {
+			t.Errorf("Expected module file %s: %v", file, err)
+		}
+	}
+
+	main := readFile(t, "infra/main.tf")
+	for _, want := range []string{
+		"module \"orders\" {\n  source = \"./modules/web_api\"",
+		"module \"payments\" {\n  source = \"./modules/web_api\"",
+		`function_name = "shop-dev-payments"`,
+		"module \"db\" {\n  source = \"./modules/aurora_serverless_v2\"",
+	} {
+		if !strings.Contains(main, want) {
+			t.Errorf("main.tf should contain %q:\n%s", want, main)
+		}
+	}
+	if strings.Contains(main, "resource \"") {
+		t.Errorf("main.tf should only instantiate modules:\n%s", main)
+	}
+
+	outputs := readFile(t, "infra/outputs.tf")
+	if !strings.Contains(outputs, "try(module.orders.api_url, \"N/A\")") {
+		t.Errorf("outputs.tf should read module outputs:\n%s", outputs)
+	}
+}
+
+func TestGenerateMovedBlocks(t *testing.T) {
+	chdirTemp(t)
+
+	cfg := &config.Config{Project: "shop", Cloud: "aws"}
+	if err := generator.New(cfg, moduleTestEnv()).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	moved := readFile(t, "infra/moved.tf")
+	for _, want := range []string{
+		"moved {\n  from = aws_lambda_function.orders\n  to   = module.orders.aws_lambda_function.this\n}",
+		"from = aws_iam_role.payments_lambda_role\n  to   = module.payments.aws_iam_role.lambda",
+		"from = aws_lambda_permission.orders\n  to   = module.orders.aws_lambda_permission.apigateway",
+		"from = aws_rds_cluster.db\n  to   = module.db.aws_rds_cluster.this",
+	} {
+		if !strings.Contains(moved, want) {
+			t.Errorf("moved.tf should contain %q:\n%s", want, moved)
+		}
+	}
+}
+
+func TestGenerateRegistryModules(t *testing.T) {
+	chdirTemp(t)
+
+	cfg := &config.Config{
+		Project: "shop",
+		Cloud:   "aws",
+		Modules: &config.Modules{Source: "acme/soloops/aws", Version: "~> 1.0"},
+	}
+	if err := generator.New(cfg, moduleTestEnv()).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	main := readFile(t, "infra/main.tf")
+	if !strings.Contains(main, "module \"orders\" {\n  source  = \"acme/soloops/aws//modules/web_api\"\n  version = \"~> 1.0\"") {
+		t.Errorf("main.tf should pin the registry module:\n%s", main)
+	}
+	if _, err := os.Stat("infra/modules"); !os.IsNotExist(err) {
+		t.Error("Local modules should not be written when a shared source is pinned")
+	}
+}
+
+func TestGenerateGitModules(t *testing.T) {
+	chdirTemp(t)
+
+	cfg := &config.Config{
+		Project: "shop",
+		Cloud:   "aws",
+		Modules: &config.Modules{Source: "git::https://example.com/soloops-modules.git", Version: "v1.0.0"},
+	}
+	if err := generator.New(cfg, moduleTestEnv()).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	main := readFile(t, "infra/main.tf")
+	if !strings.Contains(main, `source = "git::https://example.com/soloops-modules.git//modules/web_api?ref=v1.0.0"`) {
+		t.Errorf("main.tf should pin the git ref:\n%s", main)
+	}
+	if strings.Contains(main, "version =") {
+		t.Errorf("git modules should not set version:\n%s", main)
+	}
+}
+
+func TestConfigValidateModules(t *testing.T) {
+	tests := []struct {
+		modules config.Modules
+		wantErr string
+	}{
+		{config.Modules{Source: "acme/soloops/aws", Version: "1.0.0"}, ""},
+		{config.Modules{Source: "./shared"}, ""},
+		{config.Modules{}, "source is required"},
+		{config.Modules{Source: "./shared", Version: "1.0.0"}, "version is not supported"},
+		{config.Modules{Source: "git::https://example.com/m.git?ref=v1"}, "must not contain a query string"},
+	}
+	for _, tt := range tests {
+		cfg := &config.Config{
+			Project: "shop",
+			Cloud:   "aws",
+			Modules: &tt.modules,
+			Environments: []config.Environment{{
+				Name: "dev", Region: "us-east-1", BudgetUSD: 10,
+				Blueprints: map[string]config.Blueprint{"site": {Type: config.KindStaticSite}},
+			}},
+		}
+		err := cfg.Validate()
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("Validate(%+v) failed: %v", tt.modules, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("Validate(%+v) = %v, want %q", tt.modules, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/OplexTech/soloops-cli/pkg/config"
+	"github.com/OplexTech/soloops-cli/pkg/generator"
+	"github.com/OplexTech/soloops-cli/pkg/naming"
+	"github.com/OplexTech/soloops-cli/pkg/tf"
+)
+
+func TestNamingValidateKey(t *testing.T) {
+	for _, key := range []string{"api", "web_api", "static-site", "Site2"} {
+		if err := naming.ValidateKey(key); err != nil {
+			t.Errorf("ValidateKey(%q) failed: %v", key, err)
+		}
+	}
+	for _, key := range []string{"", "web api", "site.v2", "2fast", "_api", "a\"b", strings.Repeat("a", 65)} {
+		if err := naming.ValidateKey(key); err == nil {
+			t.Errorf("ValidateKey(%q) should fail", key)
+		}
+	}
+}
+
+func TestNamingPhysical(t *testing.T) {
+	tests := []struct {
+		resourceType string
+		parts        []string
+		want         string
+	}{
+		{"aws_lambda_function", []string{"shop", "dev", "web_api"}, "shop-dev-web_api"},
+		{"aws_s3_bucket", []string{"Shop", "dev", "web_site"}, "shop-dev-web-site"},
+		{"aws_s3_bucket", []string{"shop", "dev", "a__b--c"}, "shop-dev-a-b-c"},
+		{"aws_rds_cluster", []string{"1shop", "dev", "db"}, "r-1shop-dev-db"},
+		{"aws_iam_role", []string{"my shop", "dev", "api-lambda-role"}, "my-shop-dev-api-lambda-role"},
+	}
+	for _, tt := range tests {
+		if got := naming.Physical(tt.resourceType, tt.parts...); got != tt.want {
+			t.Errorf("Physical(%s, %v) = %q, want %q", tt.resourceType, tt.parts, got, tt.want)
+		}
+	}
+}
+
+func TestNamingPhysicalTruncatesWithHash(t *testing.T) {
+	long := strings.Repeat("a", 60)
+	first := naming.Physical("aws_lambda_function", "shop", "dev", long+"1")
+	second := naming.Physical("aws_lambda_function", "shop", "dev", long+"2")
+	if len(first) != 64 || len(second) != 64 {
+		t.Errorf("Expected names truncated to 64 characters, got %d and %d", len(first), len(second))
+	}
+	if first == second {
+		t.Error("Truncated names should stay distinct")
+	}
+
+	// Bucket names leave room for the account ID suffix
+	bucket := naming.PhysicalUnique("aws_s3_bucket", "shop", "dev", long)
+	if len(bucket)+naming.AccountSuffixLength != 63 {
+		t.Errorf("Expected bucket name of %d characters, got %q", 63-naming.AccountSuffixLength, bucket)
+	}
+}
+
+func TestNamingPhysicalKeepsCompliantNames(t *testing.T) {
+	// Names SoloOps generated before the naming rules are used as is, so
+	// existing buckets, tables and databases are not replaced
+	for _, tt := range []struct {
+		resourceType string
+		suffix       string
+	}{
+		{"aws_s3_bucket", strings.Repeat("b", 50)},
+		{"aws_s3_bucket", "a--b"},
+		{"aws_dynamodb_table", "orders--v2"},
+		{"aws_db_instance", "orders"},
+	} {
+		want := "shop-dev-" + tt.suffix
+		if got := naming.Physical(tt.resourceType, "shop", "dev", tt.suffix); got != want {
+			t.Errorf("Physical(%s, %s) = %q, want the existing name %q", tt.resourceType, tt.suffix, got, want)
+		}
+	}
+
+	// RDS identifiers may not contain "--", so such a name never existed
+	if got := naming.Physical("aws_db_instance", "shop", "dev", "a--b"); got != "shop-dev-a-b" {
+		t.Errorf("Expected a compliant RDS identifier, got %q", got)
+	}
+}
+
+func TestNamingConflicts(t *testing.T) {
+	names := []naming.Name{
+		{Blueprint: "web_site", ResourceType: "aws_s3_bucket", Value: "shop-dev-web-site"},
+		{Blueprint: "web-site", ResourceType: "aws_s3_bucket", Value: "shop-dev-web-site"},
+		{Blueprint: "api", ResourceType: "aws_lambda_function", Value: "shop-dev-web-site"},
+	}
+	err := naming.Conflicts(names)
+	if err == nil || !strings.Contains(err.Error(), "web-site, web_site") {
+		t.Fatalf("Expected bucket conflict, got %v", err)
+	}
+	if strings.Contains(err.Error(), "api") {
+		t.Errorf("Names of different resource types should not conflict: %v", err)
+	}
+
+	// RDS cluster instances and DB instances share identifiers
+	shared := []naming.Name{
+		{Blueprint: "db", ResourceType: "aws_rds_cluster_instance", Value: "shop-dev-db-1"},
+		{Blueprint: "db-1", ResourceType: "aws_db_instance", Value: "shop-dev-db-1"},
+	}
+	if err := naming.Conflicts(shared); err == nil {
+		t.Error("Expected conflict between RDS cluster instance and DB instance")
+	}
+}
+
+func TestConfigRejectsInvalidBlueprintName(t *testing.T) {
+	cfg := &config.Config{
+		Project: "shop",
+		Cloud:   "aws",
+		Environments: []config.Environment{{
+			Name: "dev", Region: "us-east-1", BudgetUSD: 10,
+			Blueprints: map[string]config.Blueprint{"my site": {Type: config.KindStaticSite}},
+		}},
+	}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), `invalid name "my site"`) {
+		t.Errorf("Expected invalid blueprint name error, got %v", err)
+	}
+}
+
+func TestGeneratorDerivesCompliantNames(t *testing.T) {
+	chdirTemp(t)
+
+	cfg := &config.Config{Project: "Shop", Cloud: "aws", Naming: &config.Naming{AccountSuffix: true}}
+	env := &config.Environment{
+		Name:   "dev",
+		Region: "us-east-1",
+		Blueprints: map[string]config.Blueprint{
+			"web_site": {Type: config.KindStaticSite},
+			"orders":   {Type: config.KindDatabase, DBType: "postgres"},
+		},
+	}
+	if err := generator.New(cfg, env).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	main := readFile(t, "infra/main.tf")
+	for _, want := range []string{
+		`data "aws_caller_identity" "current" {}`,
+		`bucket_name = "shop-dev-web-site-${data.aws_caller_identity.current.account_id}"`,
+		`identifier = "shop-dev-orders"`,
+	} {
+		if !strings.Contains(main, want) {
+			t.Errorf("main.tf should contain %s:\n%s", want, main)
+		}
+	}
+}
+
+func TestGeneratorKeepsBucketNamesWithoutAccountSuffix(t *testing.T) {
+	chdirTemp(t)
+
+	cfg := &config.Config{Project: "shop", Cloud: "aws"}
+	env := &config.Environment{
+		Name:       "dev",
+		Region:     "us-east-1",
+		Blueprints: map[string]config.Blueprint{"site": {Type: config.KindStaticSite}},
+	}
+	if err := generator.New(cfg, env).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	main := readFile(t, "infra/main.tf")
+	if !strings.Contains(main, `bucket_name = "shop-dev-site"`) || strings.Contains(main, "aws_caller_identity") {
+		t.Errorf("Expected the bucket to keep its name without naming.account_suffix:\n%s", main)
+	}
+}
+
+func TestValidateReportsNameConflicts(t *testing.T) {
+	dir := chdirTemp(t)
+	writeManifest(t, dir, `project: shop
+cloud: aws
+environments:
+  - name: dev
+    region: us-east-1
+    budget_usd: 10
+    blueprints:
+      web_site:
+        type: static_site
+      web-site:
+        type: static_site
+`)
+
+	out, err := runCLI(t, &tf.Fake{}, "", "validate")
+	if err == nil {
+		t.Fatalf("Expected validate to fail, got:\n%s", out)
+	}
+	if !strings.Contains(err.Error(), `blueprints web-site, web_site all derive aws_s3_bucket name "shop-dev-web-site"`) {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	if _, err := runCLI(t, &tf.Fake{}, "", "generate"); err == nil {
+		t.Error("Expected generate to refuse conflicting names")
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/OplexTech/soloops-cli/pkg/tf"
+)
+
+func outputsFake() *tf.Fake {
+	return &tf.Fake{Outputs: map[string]tf.Output{
+		"api_api_url":       {Type: json.RawMessage(`"string"`), Value: json.RawMessage(`"https://abc.execute-api.us-east-1.amazonaws.com"`)},
+		"api_function_name": {Type: json.RawMessage(`"string"`), Value: json.RawMessage(`"shop-dev-api"`)},
+		"api_db_password":   {Sensitive: true, Type: json.RawMessage(`"string"`), Value: json.RawMessage(`"hunter2"`)},
+		"environment":       {Type: json.RawMessage(`"string"`), Value: json.RawMessage(`"dev"`)},
+		"budget_usd":        {Type: json.RawMessage(`"number"`), Value: json.RawMessage(`50`)},
+	}}
+}
+
+func TestOutputsTextGroupsByBlueprint(t *testing.T) {
+	generateProject(t)
+	fake := outputsFake()
+
+	stdout, _, err := runCLISplit(t, fake, "outputs")
+	if err != nil {
+		t.Fatalf("outputs failed: %v", err)
+	}
+
+	if got := fake.Methods(); !reflect.DeepEqual(got, []string{"output"}) {
+		t.Errorf("Expected only output, got %v", got)
+	}
+	for _, want := range []string{
+		"api:\n",
+		"api_api_url        https://abc.execute-api.us-east-1.amazonaws.com",
+		"api_db_password    (sensitive)",
+		"(shared):\n",
+		"environment  dev",
+		"budget_usd   50",
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("Expected outputs to contain %q\n%s", want, stdout)
+		}
+	}
+	if strings.Contains(stdout, "hunter2") {
+		t.Errorf("Sensitive value leaked:\n%s", stdout)
+	}
+	if strings.Index(stdout, "api:") > strings.Index(stdout, "(shared):") {
+		t.Errorf("Expected blueprints before shared outputs:\n%s", stdout)
+	}
+}
+
+func TestOutputsJSON(t *testing.T) {
+	generateProject(t)
+
+	stdout, stderr, err := runCLISplit(t, outputsFake(), "outputs", "--format", "json")
+	if err != nil {
+		t.Fatalf("outputs failed: %v", err)
+	}
+
+	var values map[string]string
+	if err := json.Unmarshal([]byte(stdout), &values); err != nil {
+		t.Fatalf("stdout is not JSON: %v\n%s", err, stdout)
+	}
+	if values["api_api_url"] != "https://abc.execute-api.us-east-1.amazonaws.com" || values["budget_usd"] != "50" {
+		t.Errorf("Unexpected values: %v", values)
+	}
+	if _, ok := values["api_db_password"]; ok {
+		t.Error("Expected sensitive output to be hidden")
+	}
+	if !strings.Contains(stderr, "1 sensitive outputs hidden") {
+		t.Errorf("Expected a note about hidden outputs on stderr, got %q", stderr)
+	}
+}
+
+func TestOutputsEnvAndDotenv(t *testing.T) {
+	generateProject(t)
+
+	stdout, _, err := runCLISplit(t, outputsFake(), "outputs", "--format", "env", "--show-sensitive")
+	if err != nil {
+		t.Fatalf("outputs failed: %v", err)
+	}
+	for _, want := range []string{
+		"export API_API_URL='https://abc.execute-api.us-east-1.amazonaws.com'\n",
+		"export API_DB_PASSWORD='hunter2'\n",
+		"export ENVIRONMENT='dev'\n",
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("Expected env output to contain %q\n%s", want, stdout)
+		}
+	}
+
+	stdout, _, err = runCLISplit(t, outputsFake(), "outputs", "--format", "dotenv")
+	if err != nil {
+		t.Fatalf("outputs failed: %v", err)
+	}
+	for _, want := range []string{
+		"API_FUNCTION_NAME=shop-dev-api\n",
+		"BUDGET_USD=50\n",
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("Expected dotenv output to contain %q\n%s", want, stdout)
+		}
+	}
+	if strings.Contains(stdout, "API_DB_PASSWORD") {
+		t.Errorf("Sensitive value leaked:\n%s", stdout)
+	}
+}
+
+func TestOutputsRequiresApply(t *testing.T) {
+	generateProject(t)
+
+	_, _, err := runCLISplit(t, &tf.Fake{}, "outputs")
+	if err == nil || !strings.Contains(err.Error(), "no outputs found") {
+		t.Errorf("Expected a no outputs error, got %v", err)
+	}
+}
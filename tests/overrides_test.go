@@ -0,0 +1,249 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/OplexTech/soloops-cli/pkg/config"
+	"github.com/OplexTech/soloops-cli/pkg/generator"
+	"github.com/OplexTech/soloops-cli/pkg/tf"
+)
+
+func TestGenerateTerraformPatches(t *testing.T) {
+	chdirTemp(t)
+
+	cfg := &config.Config{Project: "shop", Cloud: "aws"}
+	env := &config.Environment{
+		Name:   "dev",
+		Region: "us-east-1",
+		Blueprints: map[string]config.Blueprint{
+			"orders": {
+				Type: config.KindWebAPI,
+				Terraform: config.Patches{
+					"aws_lambda_function.this": {"memory_size": 512, "description": "Orders API"},
+				},
+			},
+			"payments": {Type: config.KindWebAPI},
+		},
+	}
+	if err := generator.New(cfg, env).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	main := readFile(t, "infra/main.tf")
+	if !strings.Contains(main, "module \"orders\" {\n  source = \"./modules/web_api-orders\"") ||
+		!strings.Contains(main, "module \"payments\" {\n  source = \"./modules/web_api\"") {
+		t.Errorf("Only the patched blueprint should get its own module:\n%s", main)
+	}
+
+	patched := readFile(t, "infra/modules/web_api-orders/main.tf")
+	for _, want := range []string{"memory_size   = 512", `description = "Orders API"`} {
+		if !strings.Contains(patched, want) {
+			t.Errorf("Patched module should contain %q:\n%s", want, patched)
+		}
+	}
+	if strings.Contains(patched, "var.memory_size") {
+		t.Error("Patched attributes should replace the generated value")
+	}
+
+	if shared := readFile(t, "infra/modules/web_api/main.tf"); strings.Contains(shared, "Orders API") {
+		t.Error("Patches should not leak into the shared module")
+	}
+}
+
+func TestValidateRejectsUnknownPatches(t *testing.T) {
+	tests := []struct {
+		patch   string
+		wantErr string
+	}{
+		{"aws_lambda_function.fn:\n            memory_size: 512", "no resource aws_lambda_function.fn (generated: aws_lambda_function.this,"},
+		{"aws_lambda_function.this:\n            environment: {}", "aws_lambda_function.this.environment is a block"},
+		{"lambda:\n            memory_size: 512", `"lambda" is not a resource address`},
+	}
+	for _, tt := range tests {
+		dir := chdirTemp(t)
+		writeManifest(t, dir, `project: shop
+cloud: aws
+environments:
+  - name: dev
+    region: us-east-1
+    budget_usd: 10
+    blueprints:
+      api:
+        type: web_api
+        terraform:
+          `+tt.patch+`
+`)
+
+		if _, err := runCLI(t, &tf.Fake{}, "", "validate"); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+		}
+	}
+}
+
+func TestConfigRejectsPatchesWithPinnedModules(t *testing.T) {
+	cfg := &config.Config{
+		Project: "shop",
+		Cloud:   "aws",
+		Modules: &config.Modules{Source: "acme/soloops/aws"},
+		Environments: []config.Environment{{
+			Name: "dev", Region: "us-east-1", BudgetUSD: 10,
+			Blueprints: map[string]config.Blueprint{"api": {
+				Type:      config.KindWebAPI,
+				Terraform: config.Patches{"aws_lambda_function.this": {"timeout": 30}},
+			}},
+		}},
+	}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "terraform patches need the modules generated under infra/modules") {
+		t.Errorf("Expected pinned modules error, got %v", err)
+	}
+}
+
+func TestGenerateCopiesOverrides(t *testing.T) {
+	chdirTemp(t)
+
+	override := "resource \"aws_lambda_function\" \"this\" {\n  memory_size = 1024\n}\n"
+	extra := "resource \"aws_sns_topic\" \"alerts\" {\n  name = \"alerts\"\n}\n"
+	for path, content := range map[string]string{
+		"overrides/modules/web_api/lambda_override.tf": override,
+		"overrides/alerts.tf":                          extra,
+		"overrides/README.md":                          "notes",
+	} {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg := &config.Config{Project: "shop", Cloud: "aws"}
+	env := &config.Environment{
+		Name:       "dev",
+		Region:     "us-east-1",
+		Blueprints: map[string]config.Blueprint{"api": {Type: config.KindWebAPI}},
+	}
+	if err := generator.New(cfg, env).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if got := readFile(t, "infra/modules/web_api/lambda_override.tf"); got != override {
+		t.Errorf("Override not copied into the module:\n%s", got)
+	}
+	if got := readFile(t, "infra/alerts.tf"); got != extra {
+		t.Errorf("Extra resources not copied:\n%s", got)
+	}
+	if _, err := os.Stat("infra/README.md"); !os.IsNotExist(err) {
+		t.Error("Only .tf files should be copied")
+	}
+
+	// Overrides merge with generated files but never replace them
+	if err := os.WriteFile("overrides/main.tf", []byte(extra), 0644); err != nil {
+		t.Fatal(err)
+	}
+	err := generator.New(cfg, env).Generate()
+	if err == nil || !strings.Contains(err.Error(), "name it main_override.tf") {
+		t.Errorf("Expected generated file conflict, got %v", err)
+	}
+}
+
+func TestGenerateOverridesApplyToEveryModuleVariant(t *testing.T) {
+	chdirTemp(t)
+
+	override := "resource \"aws_lambda_function\" \"this\" {\n  memory_size = 1024\n}\n"
+	specific := "resource \"aws_lambda_function\" \"this\" {\n  memory_size = 2048\n}\n"
+	for path, content := range map[string]string{
+		"overrides/modules/web_api/lambda_override.tf":        override,
+		"overrides/modules/web_api-orders/lambda_override.tf": specific,
+	} {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg := &config.Config{Project: "shop", Cloud: "aws"}
+	env := &config.Environment{
+		Name:   "dev",
+		Region: "us-east-1",
+		Blueprints: map[string]config.Blueprint{
+			"api":   {Type: config.KindWebAPI},
+			"admin": {Type: config.KindWebAPI, Protect: true},
+			"orders": {
+				Type:      config.KindWebAPI,
+				Terraform: config.Patches{"aws_lambda_function.this": {"timeout": 30}},
+			},
+		},
+	}
+	if err := generator.New(cfg, env).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	for _, module := range []string{"web_api", "web_api_protected"} {
+		if got := readFile(t, "infra/modules/"+module+"/lambda_override.tf"); got != override {
+			t.Errorf("Override not copied into %s:\n%s", module, got)
+		}
+	}
+	if got := readFile(t, "infra/modules/web_api-orders/lambda_override.tf"); got != specific {
+		t.Errorf("Expected the module's own override to win:\n%s", got)
+	}
+
+	// Overrides for modules that are not generated are rejected
+	if err := os.MkdirAll("overrides/modules/static_site", 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile("overrides/modules/static_site/cdn_override.tf", []byte(override), 0644); err != nil {
+		t.Fatal(err)
+	}
+	err := generator.New(cfg, env).Generate()
+	if err == nil || !strings.Contains(err.Error(), "no generated module matches modules/static_site") {
+		t.Errorf("Expected an unknown module error, got %v", err)
+	}
+}
+
+func TestGenerateReadsOverridesNextToManifest(t *testing.T) {
+	dir := chdirTemp(t)
+	project := filepath.Join(dir, "project")
+	if err := os.MkdirAll(filepath.Join(project, "overrides"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeManifest(t, project, `project: shop
+cloud: aws
+environments:
+  - name: dev
+    region: us-east-1
+    budget_usd: 10
+    blueprints:
+      api:
+        type: web_api
+`)
+	extra := "resource \"aws_sns_topic\" \"alerts\" {\n  name = \"alerts\"\n}\n"
+	if err := os.WriteFile(filepath.Join(project, "overrides", "alerts.tf"), []byte(extra), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if out, err := runCLI(t, &tf.Fake{}, "", "generate", "-f", "project/soloops.yaml"); err != nil {
+		t.Fatalf("generate failed: %v\n%s", err, out)
+	}
+	if got := readFile(t, "infra/alerts.tf"); got != extra {
+		t.Errorf("Expected the override next to the manifest to be copied, got %q", got)
+	}
+}
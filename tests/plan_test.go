@@ -0,0 +1,128 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/OplexTech/soloops-cli/pkg/plan"
+	"github.com/OplexTech/soloops-cli/pkg/tf"
+)
+
+const samplePlanJSON = `{
+  "format_version": "1.2",
+  "resource_changes": [
+    {"address": "aws_lambda_function.api", "mode": "managed", "type": "aws_lambda_function", "name": "api", "change": {"actions": ["create"]}},
+    {"address": "aws_iam_role.api_lambda_role", "mode": "managed", "type": "aws_iam_role", "name": "api_lambda_role", "change": {"actions": ["create"]}},
+    {"address": "aws_apigatewayv2_stage.api", "mode": "managed", "type": "aws_apigatewayv2_stage", "name": "api", "change": {"actions": ["update"]}},
+    {"address": "aws_s3_bucket.api_v2", "mode": "managed", "type": "aws_s3_bucket", "name": "api_v2", "change": {"actions": ["delete", "create"]}},
+    {"address": "aws_s3_bucket_policy.site", "mode": "managed", "type": "aws_s3_bucket_policy", "name": "site", "change": {"actions": ["no-op"]}},
+    {"address": "module.site.aws_s3_bucket.this", "module_address": "module.site", "mode": "managed", "type": "aws_s3_bucket", "name": "this", "change": {"actions": ["update"]}},
+    {"address": "aws_budgets_budget.monthly", "mode": "managed", "type": "aws_budgets_budget", "name": "monthly", "change": {"actions": ["delete"]}},
+    {"address": "data.aws_ssm_parameter.secret_x", "mode": "data", "type": "aws_ssm_parameter", "name": "secret_x", "change": {"actions": ["read"]}}
+  ]
+}`
+
+func TestPlanSummaryGroupsByBlueprint(t *testing.T) {
+	summary, err := plan.Summarize([]byte(samplePlanJSON), []string{"api", "api_v2", "site"})
+	if err != nil {
+		t.Fatalf("Failed to summarize: %v", err)
+	}
+
+	want := map[string]plan.Counts{
+		"api":            {Create: 2, Update: 1},
+		"api_v2":         {Replace: 1},
+		"site":           {Update: 1},
+		plan.SharedGroup: {Delete: 1},
+	}
+	var order []string
+	for _, g := range summary.Groups {
+		order = append(order, g.Blueprint)
+		if g.Counts != want[g.Blueprint] {
+			t.Errorf("%s: expected %+v, got %+v", g.Blueprint, want[g.Blueprint], g.Counts)
+		}
+	}
+	if strings.Join(order, ",") != "api,api_v2,site,"+plan.SharedGroup {
+		t.Errorf("Unexpected group order: %v", order)
+	}
+	if summary.Totals != (plan.Counts{Create: 2, Update: 2, Delete: 1, Replace: 1}) {
+		t.Errorf("Unexpected totals: %+v", summary.Totals)
+	}
+
+	var destructive []string
+	for _, c := range summary.Destructive() {
+		destructive = append(destructive, c.Address)
+	}
+	if strings.Join(destructive, ",") != "aws_s3_bucket.api_v2,module.site.aws_s3_bucket.this,aws_budgets_budget.monthly" {
+		t.Errorf("Unexpected destructive changes: %v", destructive)
+	}
+}
+
+func TestPlanSummaryRender(t *testing.T) {
+	summary, err := plan.Summarize([]byte(samplePlanJSON), []string{"api", "api_v2", "site"})
+	if err != nil {
+		t.Fatalf("Failed to summarize: %v", err)
+	}
+
+	text, _ := summary.Render(plan.FormatText)
+	for _, want := range []string{
+		"api:       +2 create, ~1 update, -0 destroy\n",
+		"api_v2:    +0 create, ~0 update, -0 destroy, -/+1 replace\n",
+		"-/+ aws_s3_bucket.api_v2 (replaced, data store)",
+		"~   module.site.aws_s3_bucket.this (data store)",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected text summary to contain %q\n%s", want, text)
+		}
+	}
+
+	markdown, _ := summary.Render(plan.FormatMarkdown)
+	for _, want := range []string{
+		"| api | 2 | 1 | 0 | 0 |",
+		"| **Total** | **2** | **2** | **1** | **1** |",
+		"- `aws_s3_bucket.api_v2` replace (replaced, data store)",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("Expected markdown summary to contain %q\n%s", want, markdown)
+		}
+	}
+
+	if _, err := summary.Render("yaml"); err == nil {
+		t.Error("Expected error for unknown format")
+	}
+}
+
+func TestPreviewJSONOutput(t *testing.T) {
+	generateProject(t)
+	fake := &tf.Fake{Changes: true, ShowJSON: []byte(samplePlanJSON)}
+
+	stdout, stderr, err := runCLISplit(t, fake, "preview", "--output", "json")
+	if err != nil {
+		t.Fatalf("preview failed: %v", err)
+	}
+
+	var summary plan.Summary
+	if err := json.Unmarshal([]byte(stdout), &summary); err != nil {
+		t.Fatalf("Expected stdout to be a JSON summary: %v\n%s", err, stdout)
+	}
+	if summary.Totals.Create != 2 {
+		t.Errorf("Unexpected totals: %+v", summary.Totals)
+	}
+	if !strings.Contains(stderr, "Running terraform plan") {
+		t.Errorf("Expected progress on stderr:\n%s", stderr)
+	}
+}
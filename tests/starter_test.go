@@ -0,0 +1,142 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/OplexTech/soloops-cli/pkg/starter"
+)
+
+func TestBuiltinStarters(t *testing.T) {
+	for _, s := range starter.Builtin() {
+		t.Run(s.Name, func(t *testing.T) {
+			files, err := s.Render(starter.Data{Project: "shop"})
+			if err != nil {
+				t.Fatalf("Failed to render: %v", err)
+			}
+			if len(files) == 0 {
+				t.Error("Expected starter to produce files")
+			}
+			for path, content := range files {
+				if strings.HasSuffix(path, ".tmpl") {
+					t.Errorf("Template suffix not stripped: %s", path)
+				}
+				if strings.Contains(string(content), "{{") {
+					t.Errorf("Unrendered template in %s", path)
+				}
+			}
+		})
+	}
+}
+
+func TestStarterRenderProjectName(t *testing.T) {
+	s, err := starter.Find("serverless-api-node", nil)
+	if err != nil {
+		t.Fatalf("Failed to find starter: %v", err)
+	}
+
+	files, err := s.Render(starter.Data{Project: "shop"})
+	if err != nil {
+		t.Fatalf("Failed to render: %v", err)
+	}
+
+	if _, ok := files["api/index.js"]; !ok {
+		t.Error("Expected handler at api/index.js")
+	}
+	if !strings.Contains(string(files["api/package.json"]), `"name": "shop-api"`) {
+		t.Errorf("Expected package.json to use the project name:\n%s", files["api/package.json"])
+	}
+}
+
+func TestBuiltinTemplatesAreUsableAsIs(t *testing.T) {
+	s, err := starter.Find("static-site", nil)
+	if err != nil {
+		t.Fatalf("Failed to find starter: %v", err)
+	}
+	files, err := s.Render(starter.Data{Project: "shop"})
+	if err != nil {
+		t.Fatalf("Failed to render: %v", err)
+	}
+	if !strings.Contains(string(files["site/index.html"]), "<h1>shop</h1>") {
+		t.Errorf("Expected index.html to use the project name:\n%s", files["site/index.html"])
+	}
+
+	// The source tree keeps real files for manual use
+	for _, path := range []string{
+		"../infra-templates/static-site/example-website/index.html",
+		"../infra-templates/serverless-api/package.json",
+	} {
+		if content := readFile(t, path); strings.Contains(content, "{{") {
+			t.Errorf("%s should not contain template syntax", path)
+		}
+	}
+}
+
+func TestStarterRepository(t *testing.T) {
+	repo := t.TempDir()
+	dir := filepath.Join(repo, "go-worker")
+	files := map[string]string{
+		"starter.yaml":      "description: Go worker\nblueprints:\n  - worker=web_api:node20\n",
+		"soloops.yaml.tmpl": "project: {{ .Project }}\n",
+		"cmd/main.go":       "package main\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	s, err := starter.Find("go-worker", []string{repo})
+	if err != nil {
+		t.Fatalf("Failed to find starter: %v", err)
+	}
+	if s.Description != "Go worker" || len(s.Blueprints) != 1 {
+		t.Errorf("Unexpected starter: %+v", s)
+	}
+
+	rendered, err := s.Render(starter.Data{Project: "jobs"})
+	if err != nil {
+		t.Fatalf("Failed to render: %v", err)
+	}
+	if string(rendered[starter.ManifestFile]) != "project: jobs\n" {
+		t.Errorf("Expected rendered manifest, got %q", rendered[starter.ManifestFile])
+	}
+	if _, ok := rendered["starter.yaml"]; ok {
+		t.Error("starter.yaml should not be copied into the project")
+	}
+
+	out := t.TempDir()
+	if _, err := starter.WriteFiles(out, rendered, false); err != nil {
+		t.Fatalf("Failed to write files: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(out, "cmd", "main.go")); err != nil {
+		t.Errorf("Expected cmd/main.go to be written: %v", err)
+	}
+	if _, err := starter.WriteFiles(out, rendered, false); err == nil {
+		t.Error("Expected error when files already exist")
+	}
+
+	if _, err := starter.Find("missing", []string{repo}); err == nil {
+		t.Error("Expected error for unknown starter")
+	}
+}
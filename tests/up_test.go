@@ -0,0 +1,143 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/OplexTech/soloops-cli/pkg/tf"
+)
+
+func TestUpRunsPipeline(t *testing.T) {
+	dir := chdirTemp(t)
+	writeManifest(t, dir, lifecycleConfig)
+	fake := &tf.Fake{Changes: true}
+
+	out, err := runCLI(t, fake, "", "up", "--yes")
+	if err != nil {
+		t.Fatalf("up failed: %v\n%s", err, out)
+	}
+
+	for _, want := range []string{"[1/4] Validate", "Generated Terraform files", "[3/4] Preview", "Plan summary:", "[4/4] Apply", "✓ dev is up to date"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q\n%s", want, out)
+		}
+	}
+	if _, err := os.Stat("infra/main.tf"); err != nil {
+		t.Errorf("Expected infra/ to be generated: %v", err)
+	}
+
+	calls := fake.Calls()
+	if !reflect.DeepEqual(fake.Methods(), []string{"init", "plan", "show", "apply"}) {
+		t.Fatalf("Expected init, plan, show, apply; got %v", fake.Methods())
+	}
+	planOpts := calls[1].Options.(tf.PlanOptions)
+	applyOpts := calls[3].Options.(tf.ApplyOptions)
+	if applyOpts.PlanFile == "" || applyOpts.PlanFile != planOpts.Out {
+		t.Errorf("Expected the previewed plan to be applied, got plan %q apply %+v", planOpts.Out, applyOpts)
+	}
+}
+
+func TestUpSkipsGenerateWhenUnchanged(t *testing.T) {
+	dir := chdirTemp(t)
+	writeManifest(t, dir, lifecycleConfig)
+
+	if _, err := runCLI(t, &tf.Fake{}, "", "up", "--yes"); err != nil {
+		t.Fatalf("up failed: %v", err)
+	}
+
+	out, err := runCLI(t, &tf.Fake{}, "", "up", "--yes")
+	if err != nil {
+		t.Fatalf("up failed: %v", err)
+	}
+	if !strings.Contains(out, "up to date with the manifest and overrides (skipped)") {
+		t.Errorf("Expected generate to be skipped:\n%s", out)
+	}
+
+	// Overrides are part of the generated output
+	if err := os.MkdirAll("overrides", 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile("overrides/extra.tf", []byte("# extra\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	out, err = runCLI(t, &tf.Fake{}, "", "up", "--yes")
+	if err != nil {
+		t.Fatalf("up failed: %v", err)
+	}
+	if !strings.Contains(out, "Generated Terraform files") || readFile(t, "infra/extra.tf") != "# extra\n" {
+		t.Errorf("Expected regeneration after an overrides change:\n%s", out)
+	}
+
+	writeManifest(t, dir, strings.Replace(lifecycleConfig, "budget_usd: 50", "budget_usd: 60", 1))
+	out, err = runCLI(t, &tf.Fake{}, "", "up", "--yes")
+	if err != nil {
+		t.Fatalf("up failed: %v", err)
+	}
+	if !strings.Contains(out, "Generated Terraform files") {
+		t.Errorf("Expected regeneration after a manifest change:\n%s", out)
+	}
+}
+
+func TestUpNoChangesSkipsApply(t *testing.T) {
+	dir := chdirTemp(t)
+	writeManifest(t, dir, lifecycleConfig)
+	fake := &tf.Fake{}
+
+	out, err := runCLI(t, fake, "", "up")
+	if err != nil {
+		t.Fatalf("up failed: %v", err)
+	}
+	if strings.Contains(out, "[4/4] Apply") || !reflect.DeepEqual(fake.Methods(), []string{"init", "plan", "show"}) {
+		t.Errorf("Expected no apply without changes; calls %v\n%s", fake.Methods(), out)
+	}
+}
+
+func TestUpConfirmation(t *testing.T) {
+	dir := chdirTemp(t)
+	writeManifest(t, dir, lifecycleConfig)
+	fake := &tf.Fake{Changes: true}
+
+	out, err := runCLI(t, fake, "no\n", "up")
+	if err != nil {
+		t.Fatalf("up failed: %v", err)
+	}
+	if !strings.Contains(out, "Aborted.") || fake.Methods()[len(fake.Calls())-1] == "apply" {
+		t.Errorf("Expected up to stop before applying; calls %v\n%s", fake.Methods(), out)
+	}
+}
+
+func TestUpStopsAtFailingStage(t *testing.T) {
+	dir := chdirTemp(t)
+	writeManifest(t, dir, strings.Replace(lifecycleConfig, "budget_usd: 50", "budget_usd: 0", 1))
+	fake := &tf.Fake{}
+
+	out, err := runCLI(t, fake, "", "up", "--yes")
+	if err == nil || !strings.Contains(err.Error(), "up stopped at validate") {
+		t.Fatalf("Expected validate failure, got %v", err)
+	}
+	if !strings.Contains(out, "✗ validate failed") || len(fake.Calls()) != 0 {
+		t.Errorf("Expected no later stages; calls %v\n%s", fake.Methods(), out)
+	}
+
+	writeManifest(t, dir, lifecycleConfig)
+	fake = &tf.Fake{Errors: map[string]error{"plan": os.ErrPermission}}
+	if _, err := runCLI(t, fake, "", "up", "--yes"); err == nil || !strings.Contains(err.Error(), "up stopped at preview") {
+		t.Errorf("Expected preview failure, got %v", err)
+	}
+}
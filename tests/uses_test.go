@@ -0,0 +1,173 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/OplexTech/soloops-cli/pkg/config"
+	"github.com/OplexTech/soloops-cli/pkg/generator"
+)
+
+func TestConfigValidatesUses(t *testing.T) {
+	tests := []struct {
+		blueprint config.Blueprint
+		wantErr   string
+	}{
+		{config.Blueprint{Type: config.KindWebAPI, Uses: []string{"api"}}, "a blueprint cannot use itself"},
+		{config.Blueprint{Type: config.KindWebAPI, Uses: []string{"orders"}}, `unknown blueprint "orders"`},
+		{config.Blueprint{Type: config.KindWebAPI, Uses: []string{"db", "db"}}, "db is listed twice"},
+		{config.Blueprint{Type: config.KindWebAPI, Uses: []string{"db"}, Env: map[string]string{"DB_TABLE": "x"}}, "env DB_TABLE: set by uses for db"},
+		{config.Blueprint{Type: config.KindStaticSite, Uses: []string{"db"}}, "uses is only supported for web_api blueprints"},
+	}
+	for _, tt := range tests {
+		cfg := &config.Config{
+			Project: "shop",
+			Cloud:   "aws",
+			Environments: []config.Environment{{
+				Name: "dev", Region: "us-east-1", BudgetUSD: 10,
+				Blueprints: map[string]config.Blueprint{
+					"api": tt.blueprint,
+					"db":  {Type: config.KindDatabase, DBType: "dynamodb"},
+				},
+			}},
+		}
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+		}
+	}
+}
+
+func TestGenerateUses(t *testing.T) {
+	chdirTemp(t)
+
+	cfg := &config.Config{Project: "shop", Cloud: "aws"}
+	env := &config.Environment{
+		Name:   "dev",
+		Region: "us-east-1",
+		Blueprints: map[string]config.Blueprint{
+			"api":       {Type: config.KindWebAPI, Uses: []string{"orders_db", "uploads", "sessions", "worker"}},
+			"worker":    {Type: config.KindWebAPI},
+			"uploads":   {Type: config.KindStaticSite},
+			"sessions":  {Type: config.KindDatabase, DBType: "dynamodb"},
+			"orders_db": {Type: config.KindDatabase, DBType: "aurora_serverless_v2"},
+		},
+	}
+	if err := generator.New(cfg, env).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	main := readFile(t, "infra/main.tf")
+	for _, want := range []string{
+		`Action   = ["secretsmanager:GetSecretValue"]`,
+		"Resource = [module.orders_db.secret_arn]",
+		`Resource = ["${module.uploads.bucket_arn}/*"]`,
+		"Resource = [module.uploads.bucket_arn]",
+		`Resource = [module.sessions.table_arn, "${module.sessions.table_arn}/index/*"]`,
+		"Resource = [module.worker.live_alias_arn]",
+		"security_group_id = module.orders_db.security_group_id",
+		"port              = module.orders_db.port",
+		"ORDERS_DB_ENDPOINT   = module.orders_db.endpoint",
+		"ORDERS_DB_SECRET_ARN = module.orders_db.secret_arn",
+		"SESSIONS_TABLE       = module.sessions.table_name",
+		"UPLOADS_BUCKET       = module.uploads.bucket_name",
+		"WORKER_FUNCTION      = module.worker.live_alias_arn",
+	} {
+		if !strings.Contains(main, want) {
+			t.Errorf("main.tf should contain %q:\n%s", want, main)
+		}
+	}
+	if strings.Count(main, "access_statements") != 1 {
+		t.Errorf("Only api uses other blueprints:\n%s", main)
+	}
+
+	module := readFile(t, "infra/modules/web_api/main.tf")
+	for _, want := range []string{
+		`resource "aws_iam_role_policy" "access"`,
+		"Statement = var.access_statements",
+		"vpc_access = length(var.database_access) > 0",
+		`dynamic "vpc_config"`,
+		`resource "aws_vpc_security_group_ingress_rule" "database"`,
+		"referenced_security_group_id = aws_security_group.lambda[0].id",
+	} {
+		if !strings.Contains(module, want) {
+			t.Errorf("web_api module should contain %q", want)
+		}
+	}
+
+	aurora := readFile(t, "infra/modules/aurora_serverless_v2/main.tf")
+	if !strings.Contains(aurora, "vpc_security_group_ids      = [aws_security_group.this.id]") {
+		t.Errorf("Aurora cluster should be guarded by its own security group:\n%s", aurora)
+	}
+	outputs := readFile(t, "infra/modules/aurora_serverless_v2/outputs.tf")
+	if !strings.Contains(outputs, "aws_rds_cluster.this.master_user_secret[0].secret_arn") {
+		t.Errorf("Aurora module should output the secret ARN:\n%s", outputs)
+	}
+}
+
+func TestGenerateVPCEndpoints(t *testing.T) {
+	chdirTemp(t)
+
+	cfg := &config.Config{Project: "shop", Cloud: "aws"}
+	env := &config.Environment{
+		Name:   "dev",
+		Region: "us-east-1",
+		Blueprints: map[string]config.Blueprint{
+			"api":       {Type: config.KindWebAPI, Uses: []string{"orders_db", "sessions"}},
+			"worker":    {Type: config.KindWebAPI, Uses: []string{"uploads"}},
+			"uploads":   {Type: config.KindStaticSite},
+			"sessions":  {Type: config.KindDatabase, DBType: "dynamodb"},
+			"orders_db": {Type: config.KindDatabase, DBType: "postgres"},
+		},
+	}
+	if err := generator.New(cfg, env).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	endpoints := readFile(t, "infra/endpoints.tf")
+	for _, want := range []string{
+		`resource "aws_vpc_endpoint" "secretsmanager"`,
+		`service_name        = "com.amazonaws.${var.region}.secretsmanager"`,
+		`vpc_endpoint_type   = "Interface"`,
+		"private_dns_enabled = true",
+		`resource "aws_vpc_endpoint" "dynamodb"`,
+		"route_table_ids   = data.aws_route_tables.default.ids",
+		"cidr_ipv4         = data.aws_vpc.default.cidr_block",
+	} {
+		if !strings.Contains(endpoints, want) {
+			t.Errorf("endpoints.tf should contain %q:\n%s", want, endpoints)
+		}
+	}
+	// worker is not in the VPC, so it reaches S3 directly
+	for _, unwanted := range []string{`"s3"`, `"lambda"`} {
+		if strings.Contains(endpoints, unwanted) {
+			t.Errorf("endpoints.tf should not contain %s:\n%s", unwanted, endpoints)
+		}
+	}
+	if module := readFile(t, "infra/modules/web_api/main.tf"); !strings.Contains(module, `resource "aws_vpc_security_group_egress_rule" "https"`) {
+		t.Error("Functions in the VPC should be allowed HTTPS to the endpoints")
+	}
+
+	// Without functions in the VPC there are no endpoints
+	env.Blueprints["api"] = config.Blueprint{Type: config.KindWebAPI, Uses: []string{"sessions"}}
+	if err := generator.New(cfg, env).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if _, err := os.Stat("infra/endpoints.tf"); !os.IsNotExist(err) {
+		t.Error("endpoints.tf should be removed when no function is in the VPC")
+	}
+}
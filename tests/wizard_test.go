@@ -0,0 +1,142 @@
+// Copyright 2025 SoloOps Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/OplexTech/soloops-cli/pkg/config"
+	"github.com/OplexTech/soloops-cli/pkg/wizard"
+)
+
+func TestWizardRun(t *testing.T) {
+	input := strings.Join([]string{
+		"Shop!",         // invalid project name, re-asked
+		"shop",          // project
+		"gcp",           // cloud
+		"dev,prod",      // environments
+		"us-east-1",     // invalid GCP region, re-asked
+		"europe-west1",  // dev region
+		"",              // dev budget (default)
+		"",              // prod region (default)
+		"-5",            // invalid budget, re-asked
+		"300",           // prod budget
+		"web_api,cache", // unknown blueprint, re-asked
+		"web_api",       // blueprints
+		"python3.12",    // runtime
+		"",              // require HTTPS (default yes)
+		"n",             // deny public buckets
+	}, "\n") + "\n"
+
+	var out bytes.Buffer
+	answers, err := wizard.Run(wizard.NewPrompter(strings.NewReader(input), &out), wizard.Defaults())
+	if err != nil {
+		t.Fatalf("Wizard failed: %v\nOutput:\n%s", err, out.String())
+	}
+
+	if answers.Project != "shop" || answers.Cloud != "gcp" {
+		t.Errorf("Unexpected project/cloud: %s/%s", answers.Project, answers.Cloud)
+	}
+	if len(answers.Environments) != 2 {
+		t.Fatalf("Expected 2 environments, got %d", len(answers.Environments))
+	}
+	if dev := answers.Environments[0]; dev.Region != "europe-west1" || dev.BudgetUSD != 50 {
+		t.Errorf("Unexpected dev environment: %+v", dev)
+	}
+	if prod := answers.Environments[1]; prod.Region != "us-central1" || prod.BudgetUSD != 300 {
+		t.Errorf("Unexpected prod environment: %+v", prod)
+	}
+	if len(answers.Blueprints) != 1 || answers.Blueprints[0].Option != "python3.12" {
+		t.Errorf("Unexpected blueprints: %+v", answers.Blueprints)
+	}
+	if !answers.RequireHTTPS || answers.DenyPublicS3 {
+		t.Errorf("Unexpected policies: https=%v denyPublic=%v", answers.RequireHTTPS, answers.DenyPublicS3)
+	}
+
+	// Each invalid answer is reported once
+	if got := strings.Count(out.String(), "✗"); got != 4 {
+		t.Errorf("Expected 4 validation errors, got %d:\n%s", got, out.String())
+	}
+}
+
+func TestWizardEOF(t *testing.T) {
+	var out bytes.Buffer
+	_, err := wizard.Run(wizard.NewPrompter(strings.NewReader("shop\n"), &out), wizard.Defaults())
+	if err == nil {
+		t.Error("Expected error when input ends early")
+	}
+}
+
+func TestParseBlueprintSpec(t *testing.T) {
+	tests := []struct {
+		value       string
+		expected    wizard.BlueprintSpec
+		expectError bool
+	}{
+		{"web_api:python3.12", wizard.BlueprintSpec{Name: "web_api", Type: "web_api", Option: "python3.12"}, false},
+		{"web_api", wizard.BlueprintSpec{Name: "web_api", Type: "web_api", Option: "node18"}, false},
+		{"site=static_site:shop.example.com", wizard.BlueprintSpec{Name: "site", Type: "static_site", Option: "shop.example.com"}, false},
+		{"orders=database:dynamodb", wizard.BlueprintSpec{Name: "orders", Type: "database", Option: "dynamodb"}, false},
+		{"web_api:ruby", wizard.BlueprintSpec{}, true},
+		{"queue", wizard.BlueprintSpec{}, true},
+		{"api-v2=web_api", wizard.BlueprintSpec{Name: "api-v2", Type: "web_api", Option: "node18"}, false},
+		{"bad.name=web_api", wizard.BlueprintSpec{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			spec, err := wizard.ParseBlueprintSpec(tt.value)
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if spec != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, spec)
+			}
+		})
+	}
+}
+
+func TestWizardRenderLoads(t *testing.T) {
+	answers := wizard.Defaults()
+	answers.Environments = append(answers.Environments, wizard.EnvironmentAnswer{Name: "dev", Region: "us-west-2", BudgetUSD: 25})
+	answers.Blueprints = append(answers.Blueprints, wizard.BlueprintSpec{Name: "orders", Type: "database", Option: "postgres"})
+
+	content, err := answers.Render()
+	if err != nil {
+		t.Fatalf("Failed to render: %v", err)
+	}
+
+	cfg, err := config.Load(writeManifest(t, t.TempDir(), string(content)))
+	if err != nil {
+		t.Fatalf("Failed to load rendered manifest: %v\n%s", err, content)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Rendered manifest is invalid: %v", err)
+	}
+	if cfg.APIVersion != config.CurrentAPIVersion {
+		t.Errorf("Expected apiVersion %s, got %q", config.CurrentAPIVersion, cfg.APIVersion)
+	}
+	if got := cfg.Environments[1].Blueprints["orders"].DBType; got != "postgres" {
+		t.Errorf("Expected orders db_type postgres, got %q", got)
+	}
+}